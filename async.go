@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// Async is a middleware that emits the log records in a background goroutine,
+// so that logging does not wait for the emission. See NewAsync.
+type Async struct {
+	queue   chan asyncItem
+	stopped chan struct{}
+
+	// mu guards sending to queue against closing it.
+	mu     sync.RWMutex
+	closed bool
+}
+
+// asyncItem is a log record queued by Async,
+// or a marker closing drained once the preceding records are emitted.
+type asyncItem struct {
+	ctx     context.Context
+	record  log.Record
+	next    EmitFunc
+	drained chan struct{}
+}
+
+// NewAsync returns an Async queueing up to size log records.
+// Logging blocks while the queue is full.
+//
+//	async := olog.NewAsync(1024)
+//	defer async.Close()
+//	logger := olog.New(olog.Options{Middleware: []olog.Middleware{async.Middleware}})
+//
+// The middleware is safe for concurrent use and can be shared by loggers.
+// The records are emitted in the order they are queued. Options.Tap,
+// Options.ErrorCounter, and Options.TrackLastEmitted observe them on the
+// background goroutine, after they are dequeued.
+func NewAsync(size int) *Async {
+	a := &Async{
+		queue:   make(chan asyncItem, size),
+		stopped: make(chan struct{}),
+	}
+	go a.run()
+	return a
+}
+
+// Middleware is the Middleware queueing the log records passed to next.
+func (a *Async) Middleware(next EmitFunc) EmitFunc {
+	return func(ctx context.Context, r log.Record) {
+		a.mu.RLock()
+		defer a.mu.RUnlock()
+		if a.closed {
+			next(ctx, r)
+			return
+		}
+		a.queue <- asyncItem{
+			// The record is emitted after the call returns.
+			ctx:    context.WithoutCancel(ctx),
+			record: r.Clone(),
+			next:   next,
+		}
+	}
+}
+
+// Drain blocks until the log records queued before it is called are emitted
+// or ctx is done, in which case it returns the context error.
+// It is intended for tests, which otherwise would have to sleep
+// before asserting the emitted records.
+func (a *Async) Drain(ctx context.Context) error {
+	drained := make(chan struct{})
+	if err := a.enqueue(ctx, asyncItem{drained: drained}); err != nil {
+		return err
+	}
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// enqueue queues item unless Async is closed, in which case the queue
+// is already drained, or ctx is done.
+func (a *Async) enqueue(ctx context.Context, item asyncItem) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.closed {
+		close(item.drained)
+		return nil
+	}
+	select {
+	case a.queue <- item:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close emits the queued log records and stops the background goroutine.
+// The records logged afterwards are emitted synchronously.
+// It always returns nil.
+func (a *Async) Close() error {
+	a.mu.Lock()
+	if !a.closed {
+		a.closed = true
+		close(a.queue)
+	}
+	a.mu.Unlock()
+	<-a.stopped
+	return nil
+}
+
+// run emits the queued log records until the queue is closed.
+func (a *Async) run() {
+	defer close(a.stopped)
+	for item := range a.queue {
+		if item.drained != nil {
+			close(item.drained)
+			continue
+		}
+		item.next(item.ctx, item.record)
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+)
+
+func TestAsync_Drain(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	async := NewAsync(8)
+	t.Cleanup(func() { _ = async.Close() })
+	logger := New(Options{Provider: recorder, Name: "test-logger", Middleware: []Middleware{async.Middleware}})
+
+	const n = 100
+	ctx := t.Context()
+	for i := range n {
+		logger.Info(ctx, "msg", "i", i)
+	}
+	require.NoError(t, async.Drain(ctx))
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	require.Len(t, records, n)
+	for i, r := range records {
+		assert.Equal(t, []log.KeyValue{log.Int64("i", int64(i))}, r.Attributes)
+	}
+}
+
+func TestAsync_DrainContextDone(t *testing.T) {
+	block := make(chan struct{})
+	async := NewAsync(1)
+	t.Cleanup(func() { _ = async.Close() })
+	emit := async.Middleware(func(context.Context, log.Record) { <-block })
+
+	emit(t.Context(), log.Record{}) // Blocks the goroutine.
+	emit(t.Context(), log.Record{}) // Fills the queue.
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	assert.ErrorIs(t, async.Drain(ctx), context.Canceled)
+	close(block)
+	assert.NoError(t, async.Drain(t.Context()))
+}
+
+func TestAsync_Close(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	async := NewAsync(8)
+	logger := New(Options{Provider: recorder, Name: "test-logger", Middleware: []Middleware{async.Middleware}})
+
+	ctx := t.Context()
+	logger.Info(ctx, "queued")
+	require.NoError(t, async.Close())
+	require.NoError(t, async.Close(), "second close")
+	logger.Info(ctx, "synchronous")
+	require.NoError(t, async.Drain(ctx))
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	require.Len(t, records, 2)
+	assert.Equal(t, log.StringValue("queued"), records[0].Body)
+	assert.Equal(t, log.StringValue("synchronous"), records[1].Body)
+}
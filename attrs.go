@@ -0,0 +1,182 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// attrNode holds the attributes added with a single With or WithAttr call.
+// A derived logger references the attributes of its parent through the
+// parent node instead of copying them, so deriving many loggers from a base
+// with many attributes only stores each derived logger's own attributes.
+type attrNode struct {
+	parent *attrNode
+	attrs  []log.KeyValue
+	// lazy computes the attributes of a node added with WithAttrsLazy.
+	lazy *lazyAttrs
+	// valuer resolves the value of the attribute of a node added with WithLogValuer.
+	valuer LogValuer
+	// len is the number of attributes of the node and all its ancestors.
+	// It is only set if there are no lazy nodes.
+	len int
+	// hasLazy reports whether the node or any of its ancestors is lazy.
+	hasLazy bool
+}
+
+// lazyAttrs computes attributes once, on first use.
+type lazyAttrs struct {
+	once      sync.Once
+	fn        func() []log.KeyValue
+	namespace string
+	attrs     []log.KeyValue
+}
+
+// get returns the attributes computed by fn with the keys prefixed with the namespace.
+func (a *lazyAttrs) get() []log.KeyValue {
+	a.once.Do(func() {
+		attrs := a.fn()
+		a.attrs = make([]log.KeyValue, 0, len(attrs))
+		for _, kv := range attrs {
+			kv.Key = a.namespace + kv.Key
+			a.attrs = append(a.attrs, kv)
+		}
+		a.fn = nil
+	})
+	return a.attrs
+}
+
+// newAttrNode returns a node with attrs added to the attributes of parent.
+func newAttrNode(parent *attrNode, attrs []log.KeyValue) *attrNode {
+	n := &attrNode{
+		parent:  parent,
+		attrs:   attrs,
+		hasLazy: parent != nil && parent.hasLazy,
+	}
+	if !n.hasLazy {
+		n.len = parent.Len() + len(attrs)
+	}
+	return n
+}
+
+// newLazyAttrNode returns a node with the attributes computed by fn on first use
+// added to the attributes of parent. The keys are prefixed with namespace.
+func newLazyAttrNode(parent *attrNode, fn func() []log.KeyValue, namespace string) *attrNode {
+	return &attrNode{
+		parent:  parent,
+		lazy:    &lazyAttrs{fn: fn, namespace: namespace},
+		hasLazy: true,
+	}
+}
+
+// newValuerAttrNode returns a node with the attribute with the given key and
+// the value resolved by v each time it is used added to the attributes of parent.
+func newValuerAttrNode(parent *attrNode, key string, v LogValuer) *attrNode {
+	n := newAttrNode(parent, []log.KeyValue{{Key: key}})
+	n.valuer = v
+	return n
+}
+
+// own returns the attributes of the node without its ancestors.
+func (n *attrNode) own() []log.KeyValue {
+	if n.lazy != nil {
+		return n.lazy.get()
+	}
+	if n.valuer != nil {
+		return []log.KeyValue{{Key: n.attrs[0].Key, Value: n.valuer.LogValue()}}
+	}
+	return n.attrs
+}
+
+// Len returns the number of attributes of the node and all its ancestors.
+// It computes the attributes of lazy nodes.
+func (n *attrNode) Len() int {
+	if n == nil {
+		return 0
+	}
+	if !n.hasLazy {
+		return n.len
+	}
+	return n.parent.Len() + len(n.own())
+}
+
+// All returns the attributes of the node and all its ancestors,
+// the ancestors' attributes first.
+func (n *attrNode) All() []log.KeyValue {
+	return n.appendTo(make([]log.KeyValue, 0, n.Len()))
+}
+
+// appendTo appends the attributes of the ancestors and the node to dst.
+func (n *attrNode) appendTo(dst []log.KeyValue) []log.KeyValue {
+	if n == nil {
+		return dst
+	}
+	dst = n.parent.appendTo(dst)
+	return append(dst, n.own()...)
+}
+
+// addTo adds the attributes of the ancestors and the node to the record.
+func (n *attrNode) addTo(record *log.Record) {
+	if n == nil {
+		return
+	}
+	n.parent.addTo(record)
+	record.AddAttributes(n.own()...)
+}
+
+// Group returns an attribute with the given attributes bundled into
+// a map value under key.
+func Group(key string, attrs ...log.KeyValue) log.KeyValue {
+	return log.Map(key, attrs...)
+}
+
+// Enum returns an attribute with the numeric code and the name of an
+// enumerated value bundled into a map value under key:
+//
+//	olog.Enum("state", state, int64(state))
+//	// state={code=2, name="running"}
+func Enum(key string, v fmt.Stringer, code int64) log.KeyValue {
+	return log.Map(key, log.Int64("code", code), log.String("name", v.String()))
+}
+
+// Attrer is implemented by values that describe their own log representation
+// as attributes. See Options.ExpandAttrers.
+type Attrer interface {
+	LogAttrs() []log.KeyValue
+}
+
+// LogValuer is implemented by values that are resolved to a log value
+// each time a log record is emitted. See Logger.WithLogValuer.
+type LogValuer interface {
+	LogValue() log.Value
+}
+
+// LogValuerFunc is an adapter to use a function as a LogValuer.
+type LogValuerFunc func() log.Value
+
+// LogValue returns f().
+func (f LogValuerFunc) LogValue() log.Value {
+	return f()
+}
+
+// AttrsSince returns the attributes added with With, WithAttr, and the other
+// With methods to the logger since it was derived from parent, to debug
+// the composition of loggers. As deriving appends attributes, they are the
+// attributes following the ones of parent. If the logger is not derived
+// from parent, or parent is nil, all attributes of the logger are returned.
+func (l *Logger) AttrsSince(parent *Logger) []log.KeyValue {
+	all := l.attrs.All()
+	if parent == nil {
+		return all
+	}
+	base := parent.attrs.All()
+	if len(base) > len(all) || !slices.EqualFunc(all[:len(base)], base, log.KeyValue.Equal) {
+		return all
+	}
+	return all[len(base):]
+}
@@ -0,0 +1,348 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+	"go.opentelemetry.io/otel/log/noop"
+)
+
+func TestLogger_DerivedAttrsOrder(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	base := New(Options{Provider: recorder, Name: "test-logger"}).
+		WithAttr(log.String("base1", "a"), log.String("base2", "b"))
+
+	child1 := base.With("child", 1)
+	child2 := base.WithAttr(log.Int("child", 2))
+	grandchild := child1.With("grandchild", true)
+
+	ctx := t.Context()
+	child1.Info(ctx, "child1", "call", "x")
+	child2.Info(ctx, "child2")
+	grandchild.Info(ctx, "grandchild")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(records))
+	}
+	assert.Equal(t, []log.KeyValue{
+		log.String("base1", "a"),
+		log.String("base2", "b"),
+		log.Int64("child", 1),
+		log.String("call", "x"),
+	}, records[0].Attributes)
+	assert.Equal(t, []log.KeyValue{
+		log.String("base1", "a"),
+		log.String("base2", "b"),
+		log.Int("child", 2),
+	}, records[1].Attributes)
+	assert.Equal(t, []log.KeyValue{
+		log.String("base1", "a"),
+		log.String("base2", "b"),
+		log.Int64("child", 1),
+		log.Bool("grandchild", true),
+	}, records[2].Attributes)
+}
+
+func TestLogger_WithAttrCopiesInput(t *testing.T) {
+	attrs := []log.KeyValue{log.String("key", "original")}
+	logger := New(Options{Provider: noop.NewLoggerProvider(), Name: "test"}).WithAttr(attrs...)
+
+	attrs[0] = log.String("key", "modified")
+
+	assert.Equal(t, []log.KeyValue{log.String("key", "original")}, logger.attrs.All())
+}
+
+func TestGroup(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"})
+
+	logger.InfoAttr(t.Context(), "req",
+		Group("http",
+			log.String("method", "GET"),
+			Group("response", log.Int("status", 200)),
+		),
+	)
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+	assert.Equal(t, []log.KeyValue{
+		log.Map("http",
+			log.String("method", "GET"),
+			log.Map("response", log.Int64("status", 200)),
+		),
+	}, records[0].Attributes)
+}
+
+func TestGroup_Empty(t *testing.T) {
+	kv := Group("empty")
+	assert.Equal(t, "empty", kv.Key)
+	assert.Equal(t, log.KindMap, kv.Value.Kind())
+	assert.Empty(t, kv.Value.AsMap())
+}
+
+type state int
+
+const (
+	stateIdle state = iota
+	stateRunning
+)
+
+func (s state) String() string {
+	switch s {
+	case stateIdle:
+		return "idle"
+	case stateRunning:
+		return "running"
+	default:
+		return "unknown"
+	}
+}
+
+func TestEnum(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"})
+
+	logger.InfoAttr(t.Context(), "transition",
+		Enum("from", stateIdle, int64(stateIdle)),
+		Enum("to", stateRunning, int64(stateRunning)),
+	)
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+	assert.Equal(t, []log.KeyValue{
+		log.Map("from", log.Int64("code", 0), log.String("name", "idle")),
+		log.Map("to", log.Int64("code", 1), log.String("name", "running")),
+	}, records[0].Attributes)
+}
+
+type request struct {
+	method string
+	path   string
+}
+
+func (r *request) LogAttrs() []log.KeyValue {
+	if r == nil {
+		return nil
+	}
+	return []log.KeyValue{log.String("http.method", r.method), log.String("http.path", r.path)}
+}
+
+func TestOptions_ExpandAttrers(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger", ExpandAttrers: true})
+
+	ctx := t.Context()
+	logger.Info(ctx, "handled", &request{method: "GET", path: "/users"}, "status", 200)
+	logger.Info(ctx, "nil", (*request)(nil), "status", 500)
+	logger.With(&request{method: "POST", path: "/login"}).Info(ctx, "with")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	if !assert.Len(t, records, 3) {
+		return
+	}
+	assert.Equal(t, []log.KeyValue{
+		log.String("http.method", "GET"),
+		log.String("http.path", "/users"),
+		log.Int64("status", 200),
+	}, records[0].Attributes)
+	assert.Equal(t, []log.KeyValue{log.Int64("status", 500)}, records[1].Attributes)
+	assert.Equal(t, []log.KeyValue{
+		log.String("http.method", "POST"),
+		log.String("http.path", "/login"),
+	}, records[2].Attributes)
+}
+
+func TestOptions_ExpandAttrersDisabled(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"})
+
+	logger.Info(t.Context(), "handled", &request{method: "GET", path: "/users"}, "status", 200)
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	if assert.Len(t, records, 1) {
+		// The Attrer is treated as an invalid key and its pair is skipped.
+		assert.Empty(t, records[0].Attributes)
+	}
+}
+
+func TestLogger_WithAttrsLazy(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	var calls int
+	logger := New(Options{Provider: recorder, Name: "test-logger"}).
+		With("service", "api").
+		WithNamespace("build").
+		WithAttrsLazy(func() []log.KeyValue {
+			calls++
+			return []log.KeyValue{log.String("id", "abc")}
+		})
+	child := logger.With("child", true)
+	assert.Equal(t, 0, calls, "deriving loggers should not compute the attributes")
+
+	ctx := t.Context()
+	logger.Info(ctx, "first")
+	child.Info(ctx, "second")
+	logger.InfoAttr(ctx, "third")
+	assert.Equal(t, 1, calls)
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	if !assert.Len(t, records, 3) {
+		return
+	}
+	assert.Equal(t, []log.KeyValue{
+		log.String("service", "api"),
+		log.String("build.id", "abc"),
+	}, records[0].Attributes)
+	assert.Equal(t, []log.KeyValue{
+		log.String("service", "api"),
+		log.String("build.id", "abc"),
+		log.Bool("build.child", true),
+	}, records[1].Attributes)
+}
+
+func TestLogger_WithAttrsLazyUnused(t *testing.T) {
+	var calls int
+	logger := New(Options{Provider: logtest.NewRecorder(), Name: "test-logger"}).
+		WithAttrsLazy(func() []log.KeyValue {
+			calls++
+			return nil
+		})
+	_ = logger.With("key", "value").WithAttr(log.Int("n", 1))
+
+	assert.Equal(t, 0, calls)
+}
+
+func TestLogger_WithAttrsLazyConcurrent(t *testing.T) {
+	var calls atomic.Int64
+	logger := New(Options{Provider: noop.NewLoggerProvider(), Name: "test-logger"}).
+		WithAttrsLazy(func() []log.KeyValue {
+			calls.Add(1)
+			return []log.KeyValue{log.Int("n", 1)}
+		})
+
+	ctx := t.Context()
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			logger.Info(ctx, "msg")
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(1), calls.Load())
+}
+
+func TestLogger_WithLogValuer(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	var size int
+	logger := New(Options{Provider: recorder, Name: "test-logger"}).
+		With("service", "api").
+		WithNamespace("queue").
+		WithLogValuer("size", LogValuerFunc(func() log.Value { return log.IntValue(size) }))
+	child := logger.With("name", "jobs")
+
+	ctx := t.Context()
+	size = 1
+	logger.Info(ctx, "first")
+	size = 5
+	child.Info(ctx, "second")
+	logger.Info(ctx, "third", "n", 3)
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	if !assert.Len(t, records, 3) {
+		return
+	}
+	assert.Equal(t, []log.KeyValue{
+		log.String("service", "api"),
+		log.Int64("queue.size", 1),
+	}, records[0].Attributes)
+	assert.Equal(t, []log.KeyValue{
+		log.String("service", "api"),
+		log.Int64("queue.size", 5),
+		log.String("queue.name", "jobs"),
+	}, records[1].Attributes)
+	assert.Equal(t, []log.KeyValue{
+		log.String("service", "api"),
+		log.Int64("queue.size", 5),
+		log.Int64("queue.n", 3),
+	}, records[2].Attributes)
+}
+
+func TestLogger_Exemplar(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		selected  bool
+		wantCalls int
+		want      []log.KeyValue
+	}{
+		{
+			name:      "selected",
+			selected:  true,
+			wantCalls: 1,
+			want:      []log.KeyValue{log.String("request.dump", "GET /users"), log.Int64("status", 200)},
+		},
+		{
+			name:      "not selected",
+			selected:  false,
+			wantCalls: 0,
+			want:      []log.KeyValue{log.Int64("status", 200)},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := logtest.NewRecorder()
+			logger := New(Options{Provider: recorder, Name: "test-logger"})
+
+			ctx := t.Context()
+			var calls int
+			l := logger.Exemplar(ctx, tt.selected, func() []log.KeyValue {
+				calls++
+				return []log.KeyValue{log.String("request.dump", "GET /users")}
+			})
+			assert.Equal(t, 0, calls, "the attributes are computed at the first emit")
+			l.Info(ctx, "handled", "status", 200)
+			l.Info(ctx, "handled", "status", 200)
+			assert.Equal(t, tt.wantCalls, calls)
+
+			records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+			if assert.Len(t, records, 2) {
+				assert.Equal(t, tt.want, records[0].Attributes)
+				assert.Equal(t, tt.want, records[1].Attributes)
+			}
+		})
+	}
+}
+
+func TestLogger_AttrsSince(t *testing.T) {
+	logger := New(Options{Provider: logtest.NewRecorder(), Name: "test-logger"})
+	parent := logger.With("service", "api")
+	child := parent.WithNamespace("db").With("pool", 1).WithAttr(log.String("table", "users"))
+
+	assert.Equal(t, []log.KeyValue{
+		log.Int64("db.pool", 1),
+		log.String("db.table", "users"),
+	}, child.AttrsSince(parent))
+	assert.Empty(t, parent.AttrsSince(parent))
+	assert.Equal(t, []log.KeyValue{log.String("service", "api")}, parent.AttrsSince(logger))
+
+	unrelated := logger.With("other", true)
+	assert.Equal(t, []log.KeyValue{
+		log.String("service", "api"),
+		log.Int64("db.pool", 1),
+		log.String("db.table", "users"),
+	}, child.AttrsSince(unrelated))
+	assert.Equal(t, []log.KeyValue{log.String("service", "api")}, parent.AttrsSince(nil))
+}
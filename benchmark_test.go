@@ -1,6 +1,7 @@
 package olog
 
 import (
+	"fmt"
 	"testing"
 
 	"go.opentelemetry.io/otel/log"
@@ -16,6 +17,28 @@ func BenchmarkLogger_Info(b *testing.B) {
 	}
 }
 
+func BenchmarkLogger_InfoSinglePair(b *testing.B) {
+	logger := New(Options{Provider: noop.NewLoggerProvider(), Name: "bench"})
+	ctx := b.Context()
+	args := []any{"iteration", 1}
+
+	b.Run("Info", func(b *testing.B) {
+		for i := 0; b.Loop(); i++ {
+			logger.Info(ctx, "benchmark message", "iteration", i)
+		}
+	})
+	b.Run("FastPath", func(b *testing.B) {
+		for b.Loop() {
+			_ = logger.convertArgsToKeyValues(args)
+		}
+	})
+	b.Run("Loop", func(b *testing.B) {
+		for b.Loop() {
+			_ = logger.appendArgsAsKeyValues(make([]log.KeyValue, 0, len(args)/2+1), args)
+		}
+	})
+}
+
 func BenchmarkLogger_InfoWithEnabled(b *testing.B) {
 	logger := New(Options{Provider: noop.NewLoggerProvider(), Name: "bench"})
 	ctx := b.Context()
@@ -37,6 +60,44 @@ func BenchmarkLogger_With(b *testing.B) {
 	}
 }
 
+func BenchmarkLogger_WithRepeated(b *testing.B) {
+	for _, size := range []int{0, 64} {
+		b.Run(fmt.Sprintf("WithCacheSize=%d", size), func(b *testing.B) {
+			logger := New(Options{Provider: noop.NewLoggerProvider(), Name: "bench", WithCacheSize: size})
+
+			b.ReportAllocs()
+			for b.Loop() {
+				_ = logger.With("service", "test", "version", "1.0.0")
+			}
+		})
+	}
+}
+
+func BenchmarkLogger_Chain(b *testing.B) {
+	logger := New(Options{Provider: noop.NewLoggerProvider(), Name: "bench"})
+
+	b.Run("Methods", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = logger.With("request_id", "r1").
+				WithAttr(log.String("user", "u1")).
+				WithNamespace("http").
+				With("method", "GET", "path", "/")
+		}
+	})
+	b.Run("Chain", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = logger.Chain(func(d *Deriver) {
+				d.With("request_id", "r1").
+					WithAttr(log.String("user", "u1")).
+					WithNamespace("http").
+					With("method", "GET", "path", "/")
+			})
+		}
+	})
+}
+
 func BenchmarkLogger_Event(b *testing.B) {
 	logger := New(Options{Provider: noop.NewLoggerProvider(), Name: "bench"})
 	ctx := b.Context()
@@ -57,6 +118,16 @@ func BenchmarkLogger_InfoAttr(b *testing.B) {
 	}
 }
 
+func BenchmarkLogger_LogBuilder(b *testing.B) {
+	logger := New(Options{Provider: noop.NewLoggerProvider(), Name: "bench"})
+	ctx := b.Context()
+
+	b.ReportAllocs()
+	for i := 0; b.Loop(); i++ {
+		logger.LogBuilder(ctx, log.SeverityInfo, "benchmark message", NewAttrBuilder().Int("iteration", i).Str("data", "test"))
+	}
+}
+
 func BenchmarkLogger_InfoAttrWithEnabled(b *testing.B) {
 	logger := New(Options{Provider: noop.NewLoggerProvider(), Name: "bench"})
 	ctx := b.Context()
@@ -131,3 +202,37 @@ func BenchmarkLogger_WithComparison(b *testing.B) {
 		}
 	})
 }
+
+// BenchmarkLogger_WithAttrChildren measures the memory needed to derive
+// many child loggers from a base logger with many attributes.
+func BenchmarkLogger_WithAttrChildren(b *testing.B) {
+	const children = 10_000
+
+	baseAttrs := make([]log.KeyValue, 0, 20)
+	for i := range 20 {
+		baseAttrs = append(baseAttrs, log.Int("base", i))
+	}
+	base := New(Options{Provider: noop.NewLoggerProvider(), Name: "bench"}).WithAttr(baseAttrs...)
+
+	// Copy reproduces copying all the parent's attributes into each child.
+	b.Run("Copy", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			for i := range children {
+				combined := make([]log.KeyValue, 0, len(baseAttrs)+1)
+				combined = append(combined, baseAttrs...)
+				combined = append(combined, log.Int("child", i))
+				_ = combined
+			}
+		}
+	})
+
+	b.Run("Linked", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			for i := range children {
+				_ = base.WithAttr(log.Int("child", i))
+			}
+		}
+	})
+}
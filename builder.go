@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// AttrBuilder builds the attributes of a log record in storage reused
+// across log records. It is intended for hot paths:
+//
+//	logger.LogBuilder(ctx, log.SeverityInfo, "request handled",
+//		olog.NewAttrBuilder().Str("method", method).Int("status", status))
+//
+// An AttrBuilder must not be used after it is passed to Logger.LogBuilder.
+type AttrBuilder struct {
+	attrs []log.KeyValue
+}
+
+var attrBuilderPool = sync.Pool{
+	New: func() any {
+		return &AttrBuilder{attrs: make([]log.KeyValue, 0, 8)}
+	},
+}
+
+// NewAttrBuilder returns an empty AttrBuilder from the pool.
+func NewAttrBuilder() *AttrBuilder {
+	return attrBuilderPool.Get().(*AttrBuilder)
+}
+
+// Str adds a string attribute.
+func (b *AttrBuilder) Str(key, value string) *AttrBuilder {
+	b.attrs = append(b.attrs, log.String(key, value))
+	return b
+}
+
+// Int adds an int attribute.
+func (b *AttrBuilder) Int(key string, value int) *AttrBuilder {
+	b.attrs = append(b.attrs, log.Int(key, value))
+	return b
+}
+
+// Bool adds a bool attribute.
+func (b *AttrBuilder) Bool(key string, value bool) *AttrBuilder {
+	b.attrs = append(b.attrs, log.Bool(key, value))
+	return b
+}
+
+// Float adds a float64 attribute.
+func (b *AttrBuilder) Float(key string, value float64) *AttrBuilder {
+	b.attrs = append(b.attrs, log.Float64(key, value))
+	return b
+}
+
+// Any adds an attribute with the value converted
+// like the values of the key-value methods.
+func (b *AttrBuilder) Any(key string, value any) *AttrBuilder {
+	b.attrs = append(b.attrs, log.KeyValue{Key: key, Value: convertValue(value)})
+	return b
+}
+
+// release resets the builder and returns it to the pool.
+func (b *AttrBuilder) release() {
+	// Drop the references to the values so they can be garbage collected.
+	clear(b.attrs)
+	b.attrs = b.attrs[:0]
+	attrBuilderPool.Put(b)
+}
+
+// LogBuilder logs a message at the given level with the attributes of b
+// and returns b to the pool.
+func (l *Logger) LogBuilder(ctx context.Context, level log.Severity, msg string, b *AttrBuilder) {
+	l.logAttr(ctx, level, msg, b.attrs)
+	b.release()
+}
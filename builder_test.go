@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+)
+
+func TestLogger_LogBuilder(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"}).With("service", "api")
+
+	ctx := t.Context()
+	for i := range 2 {
+		logger.LogBuilder(ctx, log.SeverityInfo, "request handled", NewAttrBuilder().
+			Str("method", "GET").
+			Int("attempt", i).
+			Bool("cached", true).
+			Float("ratio", 0.5).
+			Any("latency", time.Millisecond))
+	}
+
+	var want []logtest.Record
+	for i := range 2 {
+		want = append(want, logtest.Record{
+			Context:  ctx,
+			Severity: log.SeverityInfo,
+			Body:     log.StringValue("request handled"),
+			Attributes: []log.KeyValue{
+				log.String("service", "api"),
+				log.String("method", "GET"),
+				log.Int("attempt", i),
+				log.Bool("cached", true),
+				log.Float64("ratio", 0.5),
+				log.Int64("latency", int64(time.Millisecond)),
+			},
+		})
+	}
+
+	got := recorder.Result()
+	logtest.AssertEqual(t, logtest.Recording{logtest.Scope{Name: "test-logger"}: want}, got,
+		logtest.Transform(func(r logtest.Record) logtest.Record {
+			r.Timestamp = time.Time{}
+			r.ObservedTimestamp = time.Time{}
+			return r
+		}),
+	)
+}
+
+func TestAttrBuilder_Release(t *testing.T) {
+	b := NewAttrBuilder().Str("key", "value")
+	attrs := b.attrs[:1]
+	b.release()
+
+	if len(b.attrs) != 0 {
+		t.Errorf("expected empty builder after release, got %d attributes", len(b.attrs))
+	}
+	if attrs[0].Key != "" || !attrs[0].Value.Empty() {
+		t.Errorf("expected released attribute to be cleared, got %v", attrs[0])
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import (
+	"runtime"
+	"runtime/debug"
+	"strings"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// readBuildInfo returns the build information. It is a variable for testing.
+var readBuildInfo = debug.ReadBuildInfo
+
+// BuildInfoAttrs returns attributes describing the build of the running binary:
+//   - service.version: the version of the main module
+//   - vcs.revision: the version control revision
+//   - vcs.time: the time of the version control revision
+//   - process.runtime.version: the Go version
+//
+// Attributes for which the information is unavailable are omitted.
+// The result is suitable for WithAttr.
+func BuildInfoAttrs() []log.KeyValue {
+	info, ok := readBuildInfo()
+	if !ok {
+		return []log.KeyValue{log.String("process.runtime.version", runtime.Version())}
+	}
+
+	var attrs []log.KeyValue
+	if info.Main.Version != "" {
+		attrs = append(attrs, log.String("service.version", info.Main.Version))
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision", "vcs.time":
+			attrs = append(attrs, log.String(setting.Key, setting.Value))
+		}
+	}
+	goVersion := info.GoVersion
+	if goVersion == "" {
+		goVersion = runtime.Version()
+	}
+	return append(attrs, log.String("process.runtime.version", goVersion))
+}
+
+// moduleVersion returns the version of the module owning the package pkg
+// according to the build information of the running binary.
+// It returns an empty string if the version cannot be determined.
+func moduleVersion(pkg string) string {
+	info, ok := readBuildInfo()
+	if !ok {
+		return ""
+	}
+
+	var path, version string
+	match := func(m *debug.Module) {
+		if m == nil || len(m.Path) <= len(path) || !inModule(pkg, m.Path) {
+			return
+		}
+		path, version = m.Path, m.Version
+		if m.Replace != nil && m.Replace.Version != "" {
+			version = m.Replace.Version
+		}
+	}
+	match(&info.Main)
+	for _, dep := range info.Deps {
+		match(dep)
+	}
+	return version
+}
+
+// inModule reports whether the package pkg belongs to the module with the path modPath.
+func inModule(pkg, modPath string) bool {
+	return pkg == modPath || strings.HasPrefix(pkg, modPath+"/")
+}
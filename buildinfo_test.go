@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"runtime"
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+)
+
+func TestBuildInfoAttrs(t *testing.T) {
+	attrs := BuildInfoAttrs()
+	assert.Contains(t, attrs, log.String("process.runtime.version", runtime.Version()))
+}
+
+func TestBuildInfoAttrsSettings(t *testing.T) {
+	orig := readBuildInfo
+	readBuildInfo = func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{
+			GoVersion: "go1.24.0",
+			Main:      debug.Module{Path: "example.com/app", Version: "v1.2.3"},
+			Settings: []debug.BuildSetting{
+				{Key: "vcs.revision", Value: "abc123"},
+				{Key: "vcs.time", Value: "2024-01-02T03:04:05Z"},
+				{Key: "GOOS", Value: "linux"},
+			},
+		}, true
+	}
+	t.Cleanup(func() { readBuildInfo = orig })
+
+	want := []log.KeyValue{
+		log.String("service.version", "v1.2.3"),
+		log.String("vcs.revision", "abc123"),
+		log.String("vcs.time", "2024-01-02T03:04:05Z"),
+		log.String("process.runtime.version", "go1.24.0"),
+	}
+	assert.Equal(t, want, BuildInfoAttrs())
+}
+
+func TestBuildInfoAttrsUnavailable(t *testing.T) {
+	orig := readBuildInfo
+	readBuildInfo = func() (*debug.BuildInfo, bool) { return nil, false }
+	t.Cleanup(func() { readBuildInfo = orig })
+
+	want := []log.KeyValue{log.String("process.runtime.version", runtime.Version())}
+	assert.Equal(t, want, BuildInfoAttrs())
+}
+
+func TestModuleVersion(t *testing.T) {
+	orig := readBuildInfo
+	readBuildInfo = func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{
+			Main: debug.Module{Path: "example.com/app", Version: "(devel)"},
+			Deps: []*debug.Module{
+				{Path: "example.com/lib", Version: "v1.0.0"},
+				{Path: "example.com/lib/v2", Version: "v2.1.0"},
+				{Path: "example.com/fork", Version: "v0.1.0", Replace: &debug.Module{Path: "example.com/myfork", Version: "v0.1.1"}},
+			},
+		}, true
+	}
+	t.Cleanup(func() { readBuildInfo = orig })
+
+	for pkg, want := range map[string]string{
+		"example.com/app":          "(devel)",
+		"example.com/app/internal": "(devel)",
+		"example.com/lib/pkg":      "v1.0.0",
+		"example.com/lib/v2/pkg":   "v2.1.0",
+		"example.com/fork":         "v0.1.1",
+		"example.com/library":      "",
+		"unknown":                  "",
+	} {
+		assert.Equal(t, want, moduleVersion(pkg), pkg)
+	}
+}
+
+func TestModuleVersionUnavailable(t *testing.T) {
+	orig := readBuildInfo
+	readBuildInfo = func() (*debug.BuildInfo, bool) { return nil, false }
+	t.Cleanup(func() { readBuildInfo = orig })
+
+	assert.Empty(t, moduleVersion("example.com/app"))
+}
+
+func TestNew_IncludeCallerModule(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger", IncludeCallerModule: true})
+
+	logger.Info(t.Context(), "hello")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	require.Len(t, records, 1)
+	var found bool
+	for _, kv := range records[0].Attributes {
+		if kv.Key == "caller.module.version" {
+			found = true
+			assert.NotEmpty(t, kv.Value.AsString())
+		}
+	}
+	assert.True(t, found, "caller.module.version attribute not found in %v", records[0].Attributes)
+}
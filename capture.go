@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// Capture runs fn with a logger derived from l whose log records are emitted
+// as usual and also collected. It returns the records emitted by the logger,
+// and the loggers derived from it, until fn returns, in the order they are
+// emitted. It is useful to assert on the records logged by a block of code:
+//
+//	records := olog.Capture(logger, func(l *olog.Logger) {
+//		process(ctx, l)
+//	})
+func Capture(l *Logger, fn func(*Logger)) []log.Record {
+	var (
+		mu      sync.Mutex
+		records []log.Record
+		done    bool
+	)
+	c := l.withOuter(func(next EmitFunc) EmitFunc {
+		return func(ctx context.Context, r log.Record) {
+			mu.Lock()
+			if !done {
+				records = append(records, r.Clone())
+			}
+			mu.Unlock()
+			next(ctx, r)
+		}
+	})
+
+	fn(c)
+
+	mu.Lock()
+	defer mu.Unlock()
+	done = true
+	return records
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+)
+
+func TestCapture(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger", MinSeverity: log.SeverityInfo})
+
+	ctx := t.Context()
+	logger.Info(ctx, "before")
+	var inner *Logger
+	records := Capture(logger, func(l *Logger) {
+		inner = l
+		l.Debug(ctx, "dropped")
+		l.Info(ctx, "inside", "n", 1)
+		l.With("child", true).WarnEvent(ctx, "derived")
+	})
+	inner.Info(ctx, "after")
+
+	require.Len(t, records, 2)
+	assert.Equal(t, log.StringValue("inside"), records[0].Body())
+	assert.Equal(t, log.SeverityInfo, records[0].Severity())
+	assert.Equal(t, "derived", records[1].EventName())
+	assert.Equal(t, 1, records[1].AttributesLen())
+
+	// The captured records are also emitted.
+	emitted := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	require.Len(t, emitted, 4)
+	assert.Equal(t, log.StringValue("before"), emitted[0].Body)
+	assert.Equal(t, log.StringValue("inside"), emitted[1].Body)
+	assert.Equal(t, "derived", emitted[2].EventName)
+	assert.Equal(t, log.StringValue("after"), emitted[3].Body)
+}
+
+func TestCaptureMiddleware(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{
+		Provider:   recorder,
+		Name:       "test-logger",
+		Middleware: []Middleware{tagMiddleware("mw")},
+	})
+
+	records := Capture(logger, func(l *Logger) {
+		l.Info(t.Context(), "msg")
+	})
+
+	require.Len(t, records, 1)
+	assert.Equal(t, 0, records[0].AttributesLen(), "records are captured before the middleware")
+	emitted := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	require.Len(t, emitted, 1)
+	assert.Equal(t, []log.KeyValue{log.Bool("mw", true)}, emitted[0].Attributes)
+}
+
+func TestCaptureNamed(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger", TenantInName: true})
+
+	ctx := t.Context()
+	records := Capture(logger, func(l *Logger) {
+		l.Info(ctx, "root")
+		l.Named("sub").Info(ctx, "named")
+		l.WithTenant("acme").Info(ctx, "tenant")
+	})
+
+	require.Len(t, records, 3, "the records of the loggers derived with Named are captured")
+	assert.Equal(t, log.StringValue("root"), records[0].Body())
+	assert.Equal(t, log.StringValue("named"), records[1].Body())
+	assert.Equal(t, log.StringValue("tenant"), records[2].Body())
+	assert.Len(t, recorder.Result()[logtest.Scope{Name: "test-logger.sub"}], 1)
+	assert.Len(t, recorder.Result()[logtest.Scope{Name: "test-logger.tenant.acme"}], 1)
+}
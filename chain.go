@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import "go.opentelemetry.io/otel/log"
+
+// Deriver accumulates the changes of Logger.Chain.
+// Its methods behave like the Logger methods of the same names.
+type Deriver struct {
+	logger    *Logger
+	namespace string
+	attrs     []log.KeyValue
+}
+
+// With adds the attributes converted from the alternating key-value arguments.
+func (d *Deriver) With(args ...any) *Deriver {
+	start := len(d.attrs)
+	d.attrs = d.logger.appendArgsAsKeyValues(d.attrs, args)
+	d.prefix(start)
+	return d
+}
+
+// WithAttr adds the attributes.
+func (d *Deriver) WithAttr(attrs ...log.KeyValue) *Deriver {
+	start := len(d.attrs)
+	d.attrs = append(d.attrs, attrs...)
+	d.prefix(start)
+	return d
+}
+
+// WithNamespace prefixes the keys of attributes added afterwards and the names
+// of logged events with name followed by a dot.
+func (d *Deriver) WithNamespace(name string) *Deriver {
+	d.namespace += name + "."
+	return d
+}
+
+// prefix prefixes the keys of the attributes from index start with the namespace.
+func (d *Deriver) prefix(start int) {
+	if d.namespace == "" {
+		return
+	}
+	for i := start; i < len(d.attrs); i++ {
+		d.attrs[i].Key = d.namespace + d.attrs[i].Key
+	}
+}
+
+// Chain returns a new Logger with the changes made by fn to the Deriver applied
+// at once, allocating a single logger instead of one per chained call:
+//
+//	reqLogger := logger.Chain(func(d *olog.Deriver) {
+//		d.With("request_id", id).WithNamespace("http").With("method", method, "path", path)
+//	})
+func (l *Logger) Chain(fn func(d *Deriver)) *Logger {
+	// Preallocate for the few attributes typically added, to avoid growing the slice.
+	d := Deriver{logger: l, namespace: l.namespace, attrs: make([]log.KeyValue, 0, 8)}
+	fn(&d)
+
+	c := l.clone()
+	c.namespace = d.namespace
+	if len(d.attrs) > 0 {
+		c.attrs = newAttrNode(l.attrs, d.attrs)
+	}
+	return c
+}
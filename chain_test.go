@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+	"go.opentelemetry.io/otel/log/noop"
+)
+
+func TestLogger_Chain(t *testing.T) {
+	emit := func(derive func(*Logger) *Logger) []logtest.Record {
+		recorder := logtest.NewRecorder()
+		logger := New(Options{Provider: recorder, Name: "test-logger"}).With("service", "api")
+
+		ctx := t.Context()
+		derived := derive(logger)
+		derived.Info(ctx, "request", "status", 200)
+		derived.InfoEvent(ctx, "done")
+		return recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	}
+
+	chained := emit(func(l *Logger) *Logger {
+		return l.With("request_id", "r1").
+			WithAttr(log.String("user", "u1")).
+			WithNamespace("http").
+			With("method", "GET").
+			WithAttr(log.String("path", "/"))
+	})
+	combined := emit(func(l *Logger) *Logger {
+		return l.Chain(func(d *Deriver) {
+			d.With("request_id", "r1").
+				WithAttr(log.String("user", "u1")).
+				WithNamespace("http").
+				With("method", "GET").
+				WithAttr(log.String("path", "/"))
+		})
+	})
+
+	if !assert.Len(t, combined, 2) {
+		return
+	}
+	assert.Equal(t, []log.KeyValue{
+		log.String("service", "api"),
+		log.String("request_id", "r1"),
+		log.String("user", "u1"),
+		log.String("http.method", "GET"),
+		log.String("http.path", "/"),
+		log.Int64("http.status", 200),
+	}, combined[0].Attributes)
+	assert.Equal(t, "http.done", combined[1].EventName)
+	for i := range chained {
+		assert.Equal(t, chained[i].Body, combined[i].Body)
+		assert.Equal(t, chained[i].EventName, combined[i].EventName)
+		assert.Equal(t, chained[i].Attributes, combined[i].Attributes)
+	}
+}
+
+func TestLogger_ChainEmpty(t *testing.T) {
+	logger := New(Options{Provider: noop.NewLoggerProvider(), Name: "test-logger"}).With("key", "value")
+
+	derived := logger.Chain(func(*Deriver) {})
+
+	assert.NotSame(t, logger, derived)
+	assert.Same(t, logger.attrs, derived.attrs)
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// CheckedEntry is a log record that is emitted when it is written.
+// It is returned by Logger.Check.
+type CheckedEntry struct {
+	logger *Logger
+	ctx    context.Context
+	level  log.Severity
+	msg    string
+}
+
+// Check returns a CheckedEntry if a log record with the given severity would be emitted,
+// see WillEmit. Otherwise, it returns nil.
+//
+// It allows deferring building the attributes until the level is known to be enabled:
+//
+//	if ce := logger.Check(ctx, log.SeverityDebug, "cache state"); ce != nil {
+//		ce.Write(log.String("dump", cache.Dump()))
+//	}
+func (l *Logger) Check(ctx context.Context, level log.Severity, msg string) *CheckedEntry {
+	if !l.WillEmit(ctx, level) {
+		return nil
+	}
+	return &CheckedEntry{
+		logger: l,
+		ctx:    ctx,
+		level:  level,
+		msg:    msg,
+	}
+}
+
+// Write emits the log record with the provided attributes.
+// It does nothing if e is nil.
+func (e *CheckedEntry) Write(attrs ...log.KeyValue) {
+	if e == nil {
+		return
+	}
+	e.logger.emitChecked(e.ctx, e.logger.attrRecord(e.ctx, e.level, e.msg, attrs))
+}
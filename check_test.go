@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+)
+
+func TestLogger_Check(t *testing.T) {
+	recorder := logtest.NewRecorder(logtest.WithEnabledFunc(func(_ context.Context, p log.EnabledParameters) bool {
+		return p.Severity >= log.SeverityInfo
+	}))
+	logger := New(Options{Provider: recorder, Name: "test-logger"})
+
+	ctx := t.Context()
+
+	if ce := logger.Check(ctx, log.SeverityDebug, "disabled"); ce != nil {
+		t.Errorf("expected nil entry for a disabled level, got %v", ce)
+	}
+	// Writing a nil entry is safe.
+	logger.Check(ctx, log.SeverityDebug, "disabled").Write(log.String("key", "value"))
+
+	ce := logger.Check(ctx, log.SeverityInfo, "enabled")
+	if ce == nil {
+		t.Fatal("expected non-nil entry for an enabled level")
+	}
+	ce.Write(log.String("key", "value"))
+
+	want := logtest.Recording{
+		logtest.Scope{
+			Name: "test-logger",
+		}: {
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityInfo,
+				Body:     log.StringValue("enabled"),
+				Attributes: []log.KeyValue{
+					log.String("key", "value"),
+				},
+			},
+		},
+	}
+
+	got := recorder.Result()
+	logtest.AssertEqual(t, want, got, logtest.Transform(func(r logtest.Record) logtest.Record {
+		r.Timestamp = time.Time{}
+		r.ObservedTimestamp = time.Time{}
+		return r
+	}))
+}
+
+func TestLogger_CheckSamplesOnce(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	var calls int
+	logger := New(Options{
+		Provider: recorder,
+		Name:     "test-logger",
+		Sampler: func(context.Context, log.Severity, string) bool {
+			calls++
+			return true
+		},
+	})
+
+	logger.Check(t.Context(), log.SeverityInfo, "checked").Write(log.String("key", "value"))
+
+	if calls != 1 {
+		t.Errorf("got %d sampler calls, want 1", calls)
+	}
+	if got := len(recorder.Result()[logtest.Scope{Name: "test-logger"}]); got != 1 {
+		t.Errorf("got %d records, want 1", got)
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// occurrencesKey is the attribute key of the number of log records coalesced by a Coalescer.
+const occurrencesKey = "occurrences"
+
+// Coalescer is a middleware that coalesces the log records with the same key
+// emitted within a window into a single record. See NewCoalescer.
+type Coalescer struct {
+	window time.Duration
+	keyFn  func(log.Record) string
+	now    func() time.Time
+
+	stopTicker func()
+	done       chan struct{}
+	stopped    chan struct{}
+	closeOnce  sync.Once
+
+	mu     sync.Mutex
+	closed bool
+	seq    int64
+	groups map[coalesceKey]*coalesced
+}
+
+// coalesceKey identifies the records coalesced by a Coalescer.
+type coalesceKey struct {
+	chain *emitChain
+	key   string
+}
+
+// coalesced holds the log records with the same key.
+type coalesced struct {
+	ctx    context.Context
+	record log.Record
+	next   EmitFunc
+	start  time.Time
+	seq    int64
+	// count is the number of coalesced records.
+	count int64
+}
+
+// NewCoalescer returns a Coalescer coalescing the log records of a logger
+// for which keyFn returns the same key. The first record with a key is held back, and the
+// records with the same key emitted within window from it are counted and
+// dropped. When the window expires, the first record is emitted with the
+// occurrences attribute set to the number of records it stands for.
+// Expired windows are flushed every window by a background goroutine,
+// which is stopped by Close.
+//
+// It is intended for telemetry-style records emitted in tight loops
+// with the same attributes, for example:
+//
+//	c := olog.NewCoalescer(time.Second, func(r log.Record) string { return r.Body().AsString() })
+//	defer c.Close()
+//	logger := olog.New(olog.Options{Middleware: []olog.Middleware{c.Middleware}})
+//
+// The coalescer is safe for concurrent use and can be shared by loggers.
+func NewCoalescer(window time.Duration, keyFn func(log.Record) string) *Coalescer {
+	return newCoalescer(window, keyFn, systemTicker)
+}
+
+// newCoalescer returns a Coalescer using tk, see NewCoalescer.
+func newCoalescer(window time.Duration, keyFn func(log.Record) string, tk ticker) *Coalescer {
+	ticks, stop := tk.start(window)
+	c := &Coalescer{
+		window:     window,
+		keyFn:      keyFn,
+		now:        tk.now,
+		stopTicker: stop,
+		done:       make(chan struct{}),
+		stopped:    make(chan struct{}),
+		groups:     make(map[coalesceKey]*coalesced),
+	}
+	go c.run(ticks)
+	return c
+}
+
+// Middleware is the Middleware coalescing the log records passed to next.
+func (c *Coalescer) Middleware(next EmitFunc) EmitFunc {
+	chain := &emitChain{next: next}
+	return func(ctx context.Context, r log.Record) {
+		c.emit(ctx, r, chain)
+	}
+}
+
+// Close flushes the held log records and stops the background goroutine.
+// The records emitted afterwards are passed through without coalescing.
+// It always returns nil.
+func (c *Coalescer) Close() error {
+	c.closeOnce.Do(func() {
+		c.stopTicker()
+		close(c.done)
+		<-c.stopped
+
+		c.mu.Lock()
+		c.closed = true
+		flushed := c.expired(func(*coalesced) bool { return true })
+		c.mu.Unlock()
+		emitCoalesced(flushed)
+	})
+	return nil
+}
+
+func (c *Coalescer) emit(ctx context.Context, r log.Record, chain *emitChain) {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		chain.next(ctx, r)
+		return
+	}
+	defer c.mu.Unlock()
+
+	key := coalesceKey{chain: chain, key: c.keyFn(r)}
+	if g, ok := c.groups[key]; ok {
+		g.count++
+		return
+	}
+	c.seq++
+	c.groups[key] = &coalesced{
+		// The record may be emitted after the call returns.
+		ctx:    context.WithoutCancel(ctx),
+		record: r.Clone(),
+		next:   chain.next,
+		start:  c.now(),
+		seq:    c.seq,
+		count:  1,
+	}
+}
+
+// run flushes the expired windows on every tick until Close is called.
+func (c *Coalescer) run(ticks <-chan time.Time) {
+	defer close(c.stopped)
+	for {
+		select {
+		case <-c.done:
+			return
+		case now := <-ticks:
+			c.mu.Lock()
+			flushed := c.expired(func(g *coalesced) bool { return now.Sub(g.start) >= c.window })
+			c.mu.Unlock()
+			emitCoalesced(flushed)
+		}
+	}
+}
+
+// expired removes and returns the groups for which fn returns true,
+// in the order they were started. c.mu must be held.
+func (c *Coalescer) expired(fn func(*coalesced) bool) []*coalesced {
+	var out []*coalesced
+	for key, g := range c.groups {
+		if fn(g) {
+			out = append(out, g)
+			delete(c.groups, key)
+		}
+	}
+	slices.SortFunc(out, func(a, b *coalesced) int { return cmp.Compare(a.seq, b.seq) })
+	return out
+}
+
+// emitCoalesced emits the first record of each group with the occurrences attribute.
+func emitCoalesced(groups []*coalesced) {
+	for _, g := range groups {
+		g.record.AddAttributes(log.Int64(occurrencesKey, g.count))
+		g.next(g.ctx, g.record)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+)
+
+func bodyKey(r log.Record) string { return r.Body().AsString() }
+
+func TestNewCoalescer(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)}
+	tk, ticks := clock.newTicker()
+
+	recorder := logtest.NewRecorder()
+	c := newCoalescer(time.Second, bodyKey, tk)
+	t.Cleanup(func() { _ = c.Close() })
+	logger := New(Options{Provider: recorder, Name: "test-logger", Middleware: []Middleware{c.Middleware}})
+
+	ctx := t.Context()
+	for i := range 3 {
+		logger.Info(ctx, "sample", "i", i)
+	}
+	clock.Advance(500 * time.Millisecond)
+	logger.Info(ctx, "other")
+	logger.Info(ctx, "sample", "i", 3)
+
+	// Neither window has expired.
+	ticks <- clock.Now()
+	clock.Advance(500 * time.Millisecond)
+	// Only the window of "sample" has expired.
+	ticks <- clock.Now()
+	// The second tick is received after the first is handled.
+	ticks <- clock.Now()
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	require.Len(t, records, 1)
+	assert.Equal(t, log.StringValue("sample"), records[0].Body)
+	assert.Equal(t, []log.KeyValue{log.Int64("i", 0), log.Int64(occurrencesKey, 4)}, records[0].Attributes)
+
+	clock.Advance(500 * time.Millisecond)
+	ticks <- clock.Now()
+	ticks <- clock.Now()
+
+	records = recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	require.Len(t, records, 2)
+	assert.Equal(t, log.StringValue("other"), records[1].Body)
+	assert.Equal(t, []log.KeyValue{log.Int64(occurrencesKey, 1)}, records[1].Attributes)
+}
+
+func TestCoalescer_Close(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)}
+	tk, _ := clock.newTicker()
+
+	recorder := logtest.NewRecorder()
+	c := newCoalescer(time.Minute, bodyKey, tk)
+	logger := New(Options{Provider: recorder, Name: "test-logger", Middleware: []Middleware{c.Middleware}})
+
+	ctx := t.Context()
+	logger.Info(ctx, "first")
+	logger.Warn(ctx, "second")
+	logger.Info(ctx, "first")
+	assert.Empty(t, recorder.Result()[logtest.Scope{Name: "test-logger"}])
+
+	require.NoError(t, c.Close())
+	require.NoError(t, c.Close(), "second close")
+	logger.Info(ctx, "first")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	require.Len(t, records, 3)
+	assert.Equal(t, log.StringValue("first"), records[0].Body)
+	assert.Equal(t, []log.KeyValue{log.Int64(occurrencesKey, 2)}, records[0].Attributes)
+	assert.Equal(t, log.StringValue("second"), records[1].Body)
+	assert.Equal(t, []log.KeyValue{log.Int64(occurrencesKey, 1)}, records[1].Attributes)
+	// After Close, the records are passed through.
+	assert.Equal(t, log.StringValue("first"), records[2].Body)
+	assert.Empty(t, records[2].Attributes)
+}
+
+func TestCoalescer_Loggers(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)}
+	tk, _ := clock.newTicker()
+
+	recorder := logtest.NewRecorder()
+	c := newCoalescer(time.Minute, bodyKey, tk)
+	a := New(Options{Provider: recorder, Name: "a", Middleware: []Middleware{c.Middleware}})
+	b := New(Options{Provider: recorder, Name: "b", Middleware: []Middleware{c.Middleware}})
+
+	ctx := t.Context()
+	a.Info(ctx, "msg")
+	b.Info(ctx, "msg")
+	a.Info(ctx, "msg")
+	require.NoError(t, c.Close())
+
+	result := recorder.Result()
+	recordsA := result[logtest.Scope{Name: "a"}]
+	require.Len(t, recordsA, 1)
+	assert.Equal(t, []log.KeyValue{log.Int64(occurrencesKey, 2)}, recordsA[0].Attributes)
+	recordsB := result[logtest.Scope{Name: "b"}]
+	require.Len(t, recordsB, 1, "the records of different loggers are not coalesced")
+	assert.Equal(t, []log.KeyValue{log.Int64(occurrencesKey, 1)}, recordsB[0].Attributes)
+}
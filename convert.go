@@ -4,6 +4,7 @@
 package olog // import "github.com/pellared/olog"
 
 import (
+	"encoding"
 	"fmt"
 	"math"
 	"reflect"
@@ -14,10 +15,31 @@ import (
 	"go.opentelemetry.io/otel/log"
 )
 
-// convertValue converts various types to log.Value.
+// converter converts values of key-value arguments to log.Value.
+type converter struct {
+	// timeFormat is the layout of time.Time values. If empty, they are converted to Unix nanoseconds.
+	timeFormat string
+	// runesAsStrings converts int32 values, which include runes, to single-character strings.
+	runesAsStrings bool
+	// maxDepth is the maximum nesting depth of expanded maps and slices.
+	// Deeper ones are formatted with %v. If zero, the depth is unbounded.
+	maxDepth int
+}
+
+// convertValue converts various types to log.Value using the default options.
+func convertValue(v any) log.Value {
+	return converter{}.convert(v)
+}
+
+// convert converts various types to log.Value.
+func (c converter) convert(v any) log.Value {
+	return c.convertDepth(v, 0)
+}
+
+// convertDepth converts v nested in depth maps or slices to log.Value.
 //
 //nolint:gocyclo,funlen // Ignore.
-func convertValue(v any) log.Value {
+func (c converter) convertDepth(v any, depth int) log.Value {
 	// Handling the most common types without reflect is a small perf win.
 	switch val := v.(type) {
 	case bool:
@@ -31,6 +53,9 @@ func convertValue(v any) log.Value {
 	case int16:
 		return log.Int64Value(int64(val))
 	case int32:
+		if c.runesAsStrings {
+			return log.StringValue(string(val))
+		}
 		return log.Int64Value(int64(val))
 	case int64:
 		return log.Int64Value(val)
@@ -53,19 +78,31 @@ func convertValue(v any) log.Value {
 	case time.Duration:
 		return log.Int64Value(val.Nanoseconds())
 	case complex64:
-		r := log.Float64("r", real(complex128(val)))
-		i := log.Float64("i", imag(complex128(val)))
-		return log.MapValue(r, i)
+		return log.StringValue(fmt.Sprintf("%v", val))
 	case complex128:
-		r := log.Float64("r", real(val))
-		i := log.Float64("i", imag(val))
-		return log.MapValue(r, i)
+		return log.StringValue(fmt.Sprintf("%v", val))
 	case time.Time:
+		if c.timeFormat != "" {
+			return log.StringValue(val.Format(c.timeFormat))
+		}
+		if val.IsZero() {
+			// The Unix time of the zero time overflows int64.
+			return log.Value{}
+		}
 		return log.Int64Value(val.UnixNano())
+	case *time.Time:
+		// Handled before encoding.TextMarshaler, which *time.Time implements,
+		// to be converted like time.Time.
+		if val == nil {
+			return log.Value{}
+		}
+		return c.convertDepth(*val, depth)
 	case []byte:
 		return log.BytesValue(val)
 	case error:
 		return log.StringValue(val.Error())
+	case encoding.TextMarshaler:
+		return convertTextMarshalerValue(val)
 	case attribute.Value:
 		return log.ValueFromAttribute(val)
 	case log.Value:
@@ -81,12 +118,18 @@ func convertValue(v any) log.Value {
 	case reflect.Struct:
 		return log.StringValue(fmt.Sprintf("%+v", v))
 	case reflect.Slice, reflect.Array:
+		if c.tooDeep(depth) {
+			return log.StringValue(fmt.Sprintf("%v", v))
+		}
 		items := make([]log.Value, 0, val.Len())
 		for i := 0; i < val.Len(); i++ {
-			items = append(items, convertValue(val.Index(i).Interface()))
+			items = append(items, c.convertDepth(val.Index(i).Interface(), depth+1))
 		}
 		return log.SliceValue(items...)
 	case reflect.Map:
+		if c.tooDeep(depth) {
+			return log.StringValue(fmt.Sprintf("%v", v))
+		}
 		kvs := make([]log.KeyValue, 0, val.Len())
 		for _, k := range val.MapKeys() {
 			var key string
@@ -98,7 +141,7 @@ func convertValue(v any) log.Value {
 			}
 			kvs = append(kvs, log.KeyValue{
 				Key:   key,
-				Value: convertValue(val.MapIndex(k).Interface()),
+				Value: c.convertDepth(val.MapIndex(k).Interface(), depth+1),
 			})
 		}
 		return log.MapValue(kvs...)
@@ -106,7 +149,7 @@ func convertValue(v any) log.Value {
 		if val.IsNil() {
 			return log.Value{}
 		}
-		return convertValue(val.Elem().Interface())
+		return c.convertDepth(val.Elem().Interface(), depth)
 	}
 
 	// Try to handle this as gracefully as possible.
@@ -117,6 +160,12 @@ func convertValue(v any) log.Value {
 	return log.StringValue(fmt.Sprintf("unhandled: (%s) %+v", t, v))
 }
 
+// tooDeep reports whether the maps and slices nested in depth maps or slices
+// are not expanded.
+func (c converter) tooDeep(depth int) bool {
+	return c.maxDepth > 0 && depth >= c.maxDepth
+}
+
 // convertUintValue converts a uint64 to a log.Value.
 // If the value is too large to fit in an int64, it is converted to a string.
 func convertUintValue(v uint64) log.Value {
@@ -125,3 +174,16 @@ func convertUintValue(v uint64) log.Value {
 	}
 	return log.Int64Value(int64(v))
 }
+
+// convertTextMarshalerValue converts the text representation of v to a log.Value.
+// If marshaling fails, the error is returned as a map value with an error key.
+func convertTextMarshalerValue(v encoding.TextMarshaler) log.Value {
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return log.Value{}
+	}
+	text, err := v.MarshalText()
+	if err != nil {
+		return log.MapValue(log.String("error", err.Error()))
+	}
+	return log.StringValue(string(text))
+}
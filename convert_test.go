@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"testing"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
 )
 
 func TestConvertValue(t *testing.T) {
@@ -52,6 +54,11 @@ func TestConvertValue(t *testing.T) {
 			value:     int32(2147483647),
 			wantValue: log.Int64Value(2147483647),
 		},
+		{
+			name:      "rune",
+			value:     'A',
+			wantValue: log.Int64Value(65),
+		},
 		{
 			name:      "int64",
 			value:     int64(9223372036854775807),
@@ -102,21 +109,46 @@ func TestConvertValue(t *testing.T) {
 			value:     time.Second,
 			wantValue: log.Int64Value(1_000_000_000),
 		},
+		{
+			name:      "time.Duration-zero",
+			value:     time.Duration(0),
+			wantValue: log.Int64Value(0),
+		},
+		{
+			name:      "time.Duration-negative",
+			value:     -1500 * time.Millisecond,
+			wantValue: log.Int64Value(-1_500_000_000),
+		},
 		{
 			name:      "complex64",
 			value:     complex64(complex(float32(1), float32(2))),
-			wantValue: log.MapValue(log.Float64("r", 1), log.Float64("i", 2)),
+			wantValue: log.StringValue("(1+2i)"),
 		},
 		{
 			name:      "complex128",
 			value:     complex(float64(3), float64(4)),
-			wantValue: log.MapValue(log.Float64("r", 3), log.Float64("i", 4)),
+			wantValue: log.StringValue("(3+4i)"),
 		},
 		{
 			name:      "time.Time",
 			value:     time.Unix(1000, 1000),
 			wantValue: log.Int64Value(time.Unix(1000, 1000).UnixNano()),
 		},
+		{
+			name:      "time.Time-ptr",
+			value:     func() *time.Time { ts := time.Unix(1000, 1000); return &ts }(),
+			wantValue: log.Int64Value(time.Unix(1000, 1000).UnixNano()),
+		},
+		{
+			name:      "time.Time-nil-ptr",
+			value:     (*time.Time)(nil),
+			wantValue: log.Value{},
+		},
+		{
+			name:      "time.Time-zero",
+			value:     time.Time{},
+			wantValue: log.Value{},
+		},
 		{
 			name:      "[]byte",
 			value:     []byte("hello"),
@@ -137,6 +169,26 @@ func TestConvertValue(t *testing.T) {
 			value:     fmt.Errorf("test error: %w", errors.New("nested error")),
 			wantValue: log.StringValue("test error: nested error"),
 		},
+		{
+			name:      "net.IP",
+			value:     net.IPv4(192, 0, 2, 1),
+			wantValue: log.StringValue("192.0.2.1"),
+		},
+		{
+			name:      "text_marshaler",
+			value:     textMarshaler{text: "custom"},
+			wantValue: log.StringValue("custom"),
+		},
+		{
+			name:      "text_marshaler_error",
+			value:     textMarshaler{err: errors.New("marshal failed")},
+			wantValue: log.MapValue(log.String("error", "marshal failed")),
+		},
+		{
+			name:      "text_marshaler_nil_ptr",
+			value:     (*textMarshalerPtr)(nil),
+			wantValue: log.Value{},
+		},
 		{
 			name:      "nil",
 			value:     nil,
@@ -271,3 +323,182 @@ func TestConvertValueFloat32(t *testing.T) {
 
 	assert.InDelta(t, value.AsFloat64(), want.AsFloat64(), 0.0001)
 }
+
+type textMarshaler struct {
+	text string
+	err  error
+}
+
+func (m textMarshaler) MarshalText() ([]byte, error) {
+	return []byte(m.text), m.err
+}
+
+type textMarshalerPtr struct{}
+
+func (*textMarshalerPtr) MarshalText() ([]byte, error) {
+	return []byte("ptr"), nil
+}
+
+func TestConverterTimeFormat(t *testing.T) {
+	ts := time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		name      string
+		layout    string
+		value     any
+		wantValue log.Value
+	}{
+		{
+			name:      "default",
+			layout:    "",
+			value:     ts,
+			wantValue: log.Int64Value(ts.UnixNano()),
+		},
+		{
+			name:      "RFC3339",
+			layout:    time.RFC3339,
+			value:     ts,
+			wantValue: log.StringValue("2024-03-05T14:30:00Z"),
+		},
+		{
+			name:      "custom",
+			layout:    "2006/01/02 15:04",
+			value:     ts,
+			wantValue: log.StringValue("2024/03/05 14:30"),
+		},
+		{
+			name:      "custom pointer",
+			layout:    "2006",
+			value:     &ts,
+			wantValue: log.StringValue("2024"),
+		},
+		{
+			name:      "default zero",
+			layout:    "",
+			value:     time.Time{},
+			wantValue: log.Value{},
+		},
+		{
+			name:      "RFC3339 zero",
+			layout:    time.RFC3339,
+			value:     time.Time{},
+			wantValue: log.StringValue("0001-01-01T00:00:00Z"),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := converter{timeFormat: tt.layout}
+			assert.Equal(t, tt.wantValue, c.convert(tt.value))
+		})
+	}
+}
+
+func TestLogger_TimeArgs(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger", TimeFormat: time.RFC3339})
+	started := time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)
+
+	logger.Info(t.Context(), "done", "started_at", started, "elapsed", 2*time.Second, "finished_at", time.Time{})
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	if assert.Len(t, records, 1) {
+		assert.Equal(t, []log.KeyValue{
+			log.String("started_at", "2024-03-05T14:30:00Z"),
+			log.Int64("elapsed", 2_000_000_000),
+			log.String("finished_at", "0001-01-01T00:00:00Z"),
+		}, records[0].Attributes)
+	}
+}
+
+func TestConverterRunesAsStrings(t *testing.T) {
+	c := converter{runesAsStrings: true}
+
+	assert.Equal(t, log.StringValue("A"), c.convert('A'))
+	assert.Equal(t, log.StringValue("世"), c.convert('世'))
+	assert.Equal(t, log.SliceValue(log.StringValue("o"), log.StringValue("k")), c.convert([]rune("ok")))
+	assert.Equal(t, log.Int64Value(7), c.convert(int64(7)), "other integers are not affected")
+}
+
+func TestNew_RunesAsStrings(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		runesAsStrings bool
+		want           log.Value
+	}{
+		{name: "default", runesAsStrings: false, want: log.Int64Value('x')},
+		{name: "enabled", runesAsStrings: true, want: log.StringValue("x")},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := logtest.NewRecorder()
+			logger := New(Options{Provider: recorder, Name: "test-logger", RunesAsStrings: tt.runesAsStrings})
+
+			logger.Info(t.Context(), "key pressed", "key", 'x', "complex", 1+2i)
+
+			records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+			if assert.Len(t, records, 1) {
+				assert.Equal(t, []log.KeyValue{
+					{Key: "key", Value: tt.want},
+					log.String("complex", "(1+2i)"),
+				}, records[0].Attributes)
+			}
+		})
+	}
+}
+
+func TestConverterMaxDepth(t *testing.T) {
+	nested := map[string]any{
+		"a": map[string]any{
+			"b": []int{1, 2},
+		},
+	}
+
+	for _, tt := range []struct {
+		name     string
+		maxDepth int
+		want     log.Value
+	}{
+		{
+			name:     "unbounded",
+			maxDepth: 0,
+			want: log.MapValue(log.Map("a",
+				log.Slice("b", log.Int64Value(1), log.Int64Value(2)),
+			)),
+		},
+		{
+			name:     "cut at slice",
+			maxDepth: 2,
+			want:     log.MapValue(log.Map("a", log.String("b", "[1 2]"))),
+		},
+		{
+			name:     "cut at map",
+			maxDepth: 1,
+			want:     log.MapValue(log.String("a", "map[b:[1 2]]")),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := converter{maxDepth: tt.maxDepth}
+			assert.Equal(t, tt.want, c.convert(nested))
+		})
+	}
+}
+
+func TestLogger_WithMaxDepth(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger", MaxValueDepth: 1})
+	value := [][]int{{1, 2}, {3}}
+
+	logger.Info(t.Context(), "default", "value", value)
+	logger.WithMaxDepth(0).Info(t.Context(), "unbounded", "value", value)
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	if assert.Len(t, records, 2) {
+		assert.Equal(t, []log.KeyValue{
+			log.Slice("value", log.StringValue("[1 2]"), log.StringValue("[3]")),
+		}, records[0].Attributes)
+		assert.Equal(t, []log.KeyValue{
+			log.Slice("value",
+				log.SliceValue(log.Int64Value(1), log.Int64Value(2)),
+				log.SliceValue(log.Int64Value(3)),
+			),
+		}, records[1].Attributes)
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// countKey is the attribute key of the total number of Count calls.
+const countKey = "count"
+
+// Count increments the counter of key and logs a message with optional
+// key-value pairs and the count attribute, the current total, on every
+// every-th call. If every is less than 2, every call is logged.
+// The counters are shared by the logger and the loggers derived from it
+// and are safe for concurrent use.
+//
+//	logger.Count(ctx, log.SeverityInfo, "cache.miss", 100, "cache misses")
+func (l *Logger) Count(ctx context.Context, level log.Severity, key string, every int, msg string, args ...any) {
+	counter, _ := l.cfg.counts.LoadOrStore(key, new(atomic.Int64))
+	n := counter.(*atomic.Int64).Add(1)
+	if every > 1 && n%int64(every) != 0 {
+		return
+	}
+	l.logExtra(ctx, level, msg, args, log.Int64(countKey, n))
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+)
+
+func TestLogger_Count(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"})
+
+	ctx := t.Context()
+	for range 10 {
+		logger.Count(ctx, log.SeverityInfo, "cache.miss", 5, "cache misses", "cache", "users")
+	}
+	logger.Count(ctx, log.SeverityInfo, "other", 5, "other")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	require.Len(t, records, 2)
+	for i, want := range []int64{5, 10} {
+		assert.Equal(t, log.StringValue("cache misses"), records[i].Body)
+		assert.Equal(t, []log.KeyValue{
+			log.String("cache", "users"),
+			log.Int64("count", want),
+		}, records[i].Attributes)
+	}
+}
+
+func TestLogger_CountEveryCall(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"})
+
+	ctx := t.Context()
+	logger.Count(ctx, log.SeverityInfo, "key", 0, "msg")
+	logger.With("derived", true).Count(ctx, log.SeverityInfo, "key", 1, "msg")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	require.Len(t, records, 2)
+	assert.Equal(t, log.Int64("count", 1), records[0].Attributes[0])
+	// The derived logger shares the counter.
+	assert.Equal(t, log.Int64("count", 2), records[1].Attributes[1])
+}
+
+func TestLogger_CountConcurrent(t *testing.T) {
+	var emitted int
+	var mu sync.Mutex
+	logger := New(Options{
+		Provider: logtest.NewRecorder(),
+		Name:     "test-logger",
+		Tap: func(_ context.Context, _ log.Record) {
+			mu.Lock()
+			emitted++
+			mu.Unlock()
+		},
+	})
+
+	ctx := t.Context()
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				logger.Count(ctx, log.SeverityInfo, "key", 10, "msg")
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 100, emitted)
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import (
+	"context"
+	"maps"
+	"sync"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// deprecatedEventMsg is the body of the warning emitted for a deprecated event name.
+const deprecatedEventMsg = "deprecated event name"
+
+// deprecations holds Options.DeprecatedEvents and the event names already warned about.
+type deprecations struct {
+	replacements map[string]string
+	warned       sync.Map // map[string]struct{}
+}
+
+// newDeprecations returns the deprecations of the given replacements,
+// or nil if there are none.
+func newDeprecations(replacements map[string]string) *deprecations {
+	if len(replacements) == 0 {
+		return nil
+	}
+	return &deprecations{replacements: maps.Clone(replacements)}
+}
+
+// warnDeprecatedEvent emits a Warn record noting the replacement of the event name
+// the first time a deprecated event name is emitted.
+func (l *Logger) warnDeprecatedEvent(ctx context.Context, name string) {
+	d := l.cfg.deprecations
+	if d == nil || name == "" {
+		return
+	}
+	replacement, ok := d.replacements[name]
+	if !ok {
+		return
+	}
+	if _, loaded := d.warned.LoadOrStore(name, struct{}{}); loaded {
+		return
+	}
+
+	var record log.Record
+	record.SetBody(log.StringValue(deprecatedEventMsg))
+	record.SetTimestamp(l.now())
+	record.SetSeverity(log.SeverityWarn)
+	record.AddAttributes(
+		log.String("event.deprecated", name),
+		log.String("event.replacement", replacement),
+	)
+	l.emit(ctx, record)
+}
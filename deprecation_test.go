@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+)
+
+func TestOptions_DeprecatedEvents(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{
+		Provider:         recorder,
+		Name:             "test-logger",
+		DeprecatedEvents: map[string]string{"user.signin": "user.login"},
+	})
+
+	ctx := t.Context()
+	logger.InfoEvent(ctx, "user.signin", "id", 1)
+	logger.InfoEventAttr(ctx, "user.signin", log.Int("id", 2))
+	logger.InfoEvent(ctx, "user.login", "id", 3)
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	if len(records) != 4 {
+		t.Fatalf("expected 4 records, got %d", len(records))
+	}
+
+	assert.Equal(t, "user.signin", records[0].EventName)
+	assert.Equal(t, []log.KeyValue{log.Int64("id", 1)}, records[0].Attributes)
+
+	warning := records[1]
+	assert.Equal(t, log.SeverityWarn, warning.Severity)
+	assert.Equal(t, log.StringValue(deprecatedEventMsg), warning.Body)
+	assert.Empty(t, warning.EventName)
+	assert.Equal(t, []log.KeyValue{
+		log.String("event.deprecated", "user.signin"),
+		log.String("event.replacement", "user.login"),
+	}, warning.Attributes)
+
+	assert.Equal(t, "user.signin", records[2].EventName)
+	assert.Equal(t, []log.KeyValue{log.Int64("id", 2)}, records[2].Attributes)
+	assert.Equal(t, "user.login", records[3].EventName)
+}
+
+func TestOptions_DeprecatedEventsDerived(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{
+		Provider:         recorder,
+		Name:             "test-logger",
+		DeprecatedEvents: map[string]string{"old": "new"},
+	})
+
+	ctx := t.Context()
+	logger.With("a", 1).InfoEvent(ctx, "old")
+	logger.With("b", 2).InfoEvent(ctx, "old")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	var warnings int
+	for _, r := range records {
+		if r.Body.Equal(log.StringValue(deprecatedEventMsg)) {
+			warnings++
+		}
+	}
+	assert.Len(t, records, 3)
+	assert.Equal(t, 1, warnings, "the warning should be emitted once per logger tree")
+}
@@ -105,6 +105,7 @@ olog is designed with performance in mind:
   - Logger composition with WithAttr pre-processes common attributes
   - Direct integration with OpenTelemetry Logs API avoids unnecessary conversions
   - Prefer Attr variants (TraceAttr, InfoAttr, etc.) over variadic methods for better performance and type safety
+  - Use ologtest.MeasureAllocs in tests to compare the allocations of call styles
 
 # Design Goals
 
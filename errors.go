@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// errorKey is the attribute key of logged errors.
+const errorKey = "error"
+
+// WrapError logs an error message with optional key-value pairs and the error,
+// and returns the error wrapped with the message.
+// It returns nil without logging if err is nil.
+//
+//	if err := db.Ping(ctx); err != nil {
+//		return logger.WrapError(ctx, err, "ping database", "host", host)
+//	}
+func (l *Logger) WrapError(ctx context.Context, err error, msg string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+	l.logExtra(ctx, log.SeverityError, msg, args, log.String(errorKey, err.Error()))
+	return fmt.Errorf("%s: %w", msg, err)
+}
+
+// InfoErr logs an info message with the error and optional key-value pairs,
+// for example a retryable failure. The error is omitted if err is nil.
+func (l *Logger) InfoErr(ctx context.Context, msg string, err error, args ...any) {
+	l.logErr(ctx, log.SeverityInfo, msg, err, args)
+}
+
+// WarnErr logs a warning message with the error and optional key-value pairs.
+// The error is omitted if err is nil.
+func (l *Logger) WarnErr(ctx context.Context, msg string, err error, args ...any) {
+	l.logErr(ctx, log.SeverityWarn, msg, err, args)
+}
+
+// LogError logs a message with the error and optional key-value pairs
+// at the severity reported by the Severity() log.Severity method of err
+// or an error it wraps, and at the error level otherwise.
+// The error is omitted if err is nil.
+func (l *Logger) LogError(ctx context.Context, err error, msg string, args ...any) {
+	level := log.SeverityError
+	var s interface{ Severity() log.Severity }
+	if errors.As(err, &s) {
+		level = s.Severity()
+	}
+	l.logErr(ctx, level, msg, err, args)
+}
+
+// ErrorFrom logs an error message with the message of err as the body,
+// optional key-value pairs, and the error.type attribute with the Go type of err.
+// If err is nil, it logs Options.NilErrorBody without error.type,
+// or nothing if Options.NilErrorBody is empty.
+func (l *Logger) ErrorFrom(ctx context.Context, err error, args ...any) {
+	if err == nil {
+		if l.cfg.nilErrorBody != "" {
+			l.log(ctx, log.SeverityError, l.cfg.nilErrorBody, args)
+		}
+		return
+	}
+	l.logExtra(ctx, log.SeverityError, err.Error(), args, log.String("error.type", fmt.Sprintf("%T", err)))
+}
+
+// Errors logs an error message with optional key-value pairs and the error
+// and error.type attributes for each error joined in err, for example by errors.Join,
+// so that each error is logged as its own log record.
+// If err does not have an Unwrap() []error method, a single log record is logged.
+// Nothing is logged if err is nil.
+func (l *Logger) Errors(ctx context.Context, msg string, err error, args ...any) {
+	if err == nil {
+		return
+	}
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+	for _, e := range errs {
+		if e == nil {
+			continue
+		}
+		l.logExtra(ctx, log.SeverityError, msg, args,
+			log.String(errorKey, e.Error()),
+			log.String("error.type", fmt.Sprintf("%T", e)),
+		)
+	}
+}
+
+// logErr logs a message with key-value pairs followed by the error if it is not nil.
+func (l *Logger) logErr(ctx context.Context, level log.Severity, msg string, err error, args []any) {
+	if err == nil {
+		l.log(ctx, level, msg, args)
+		return
+	}
+	l.logExtra(ctx, level, msg, args, log.String(errorKey, err.Error()))
+}
+
+// ErrorAttrs returns the attributes describing err:
+// error.message with the message, error.type with the Go type,
+// error.code if err or an error it wraps has a Code() string method,
+// and error.stacktrace if err or an error it wraps has a StackTrace() string method.
+// It returns nil if err is nil.
+func ErrorAttrs(err error) []log.KeyValue {
+	if err == nil {
+		return nil
+	}
+	attrs := []log.KeyValue{
+		log.String("error.message", err.Error()),
+		log.String("error.type", fmt.Sprintf("%T", err)),
+	}
+	var coder interface{ Code() string }
+	if errors.As(err, &coder) {
+		attrs = append(attrs, log.String("error.code", coder.Code()))
+	}
+	var stacker interface{ StackTrace() string }
+	if errors.As(err, &stacker) {
+		attrs = append(attrs, log.String("error.stacktrace", stacker.StackTrace()))
+	}
+	return attrs
+}
@@ -0,0 +1,344 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+)
+
+func TestLogger_WrapError(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"})
+
+	ctx := t.Context()
+
+	if err := logger.WrapError(ctx, nil, "ping database"); err != nil {
+		t.Errorf("expected nil error, got %v", err)
+	}
+
+	errRefused := errors.New("connection refused")
+	err := logger.WrapError(ctx, errRefused, "ping database", "host", "db.example.com")
+	if !errors.Is(err, errRefused) {
+		t.Errorf("expected wrapped error to unwrap to %v, got %v", errRefused, err)
+	}
+	if got, want := err.Error(), "ping database: connection refused"; got != want {
+		t.Errorf("got error %q, want %q", got, want)
+	}
+
+	want := logtest.Recording{
+		logtest.Scope{
+			Name: "test-logger",
+		}: {
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityError,
+				Body:     log.StringValue("ping database"),
+				Attributes: []log.KeyValue{
+					log.String("host", "db.example.com"),
+					log.String("error", "connection refused"),
+				},
+			},
+		},
+	}
+
+	got := recorder.Result()
+	logtest.AssertEqual(t, want, got, logtest.Transform(func(r logtest.Record) logtest.Record {
+		r.Timestamp = time.Time{}
+		r.ObservedTimestamp = time.Time{}
+		return r
+	}))
+}
+
+func TestLogger_InfoErrWarnErr(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"})
+
+	ctx := t.Context()
+	errTimeout := errors.New("timeout")
+	logger.InfoErr(ctx, "retrying", errTimeout, "attempt", 1)
+	logger.InfoErr(ctx, "retrying", nil, "attempt", 2)
+	logger.WarnErr(ctx, "giving up", errTimeout, "attempts", 3)
+	logger.WarnErr(ctx, "giving up", nil)
+
+	want := logtest.Recording{
+		logtest.Scope{
+			Name: "test-logger",
+		}: {
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityInfo,
+				Body:     log.StringValue("retrying"),
+				Attributes: []log.KeyValue{
+					log.Int64("attempt", 1),
+					log.String("error", "timeout"),
+				},
+			},
+			logtest.Record{
+				Context:    ctx,
+				Severity:   log.SeverityInfo,
+				Body:       log.StringValue("retrying"),
+				Attributes: []log.KeyValue{log.Int64("attempt", 2)},
+			},
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityWarn,
+				Body:     log.StringValue("giving up"),
+				Attributes: []log.KeyValue{
+					log.Int64("attempts", 3),
+					log.String("error", "timeout"),
+				},
+			},
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityWarn,
+				Body:     log.StringValue("giving up"),
+			},
+		},
+	}
+
+	got := recorder.Result()
+	logtest.AssertEqual(t, want, got, logtest.Transform(func(r logtest.Record) logtest.Record {
+		r.Timestamp = time.Time{}
+		r.ObservedTimestamp = time.Time{}
+		return r
+	}))
+}
+
+type codeError struct{ code string }
+
+func (e codeError) Error() string { return "failed with " + e.code }
+
+func (e codeError) Code() string { return e.code }
+
+type stackError struct{}
+
+func (stackError) Error() string { return "failed" }
+
+func (stackError) StackTrace() string { return "main.main()" }
+
+func TestErrorAttrs(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+		want []log.KeyValue
+	}{
+		{
+			name: "nil",
+			err:  nil,
+			want: nil,
+		},
+		{
+			name: "plain",
+			err:  errors.New("boom"),
+			want: []log.KeyValue{
+				log.String("error.message", "boom"),
+				log.String("error.type", "*errors.errorString"),
+			},
+		},
+		{
+			name: "code",
+			err:  codeError{code: "E42"},
+			want: []log.KeyValue{
+				log.String("error.message", "failed with E42"),
+				log.String("error.type", "olog.codeError"),
+				log.String("error.code", "E42"),
+			},
+		},
+		{
+			name: "wrapped code",
+			err:  fmt.Errorf("query: %w", codeError{code: "E42"}),
+			want: []log.KeyValue{
+				log.String("error.message", "query: failed with E42"),
+				log.String("error.type", "*fmt.wrapError"),
+				log.String("error.code", "E42"),
+			},
+		},
+		{
+			name: "stack",
+			err:  stackError{},
+			want: []log.KeyValue{
+				log.String("error.message", "failed"),
+				log.String("error.type", "olog.stackError"),
+				log.String("error.stacktrace", "main.main()"),
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ErrorAttrs(tt.err)
+			if !equalKeyValues(got, tt.want) {
+				t.Errorf("ErrorAttrs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type severityError struct{ level log.Severity }
+
+func (e severityError) Error() string { return "classified" }
+
+func (e severityError) Severity() log.Severity { return e.level }
+
+func TestLogger_LogError(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"})
+
+	ctx := t.Context()
+	logger.LogError(ctx, severityError{level: log.SeverityWarn}, "classified failure", "id", 1)
+	logger.LogError(ctx, fmt.Errorf("wrapped: %w", severityError{level: log.SeverityInfo}), "wrapped failure")
+	logger.LogError(ctx, errors.New("plain"), "plain failure")
+
+	want := logtest.Recording{
+		logtest.Scope{
+			Name: "test-logger",
+		}: {
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityWarn,
+				Body:     log.StringValue("classified failure"),
+				Attributes: []log.KeyValue{
+					log.Int64("id", 1),
+					log.String("error", "classified"),
+				},
+			},
+			logtest.Record{
+				Context:    ctx,
+				Severity:   log.SeverityInfo,
+				Body:       log.StringValue("wrapped failure"),
+				Attributes: []log.KeyValue{log.String("error", "wrapped: classified")},
+			},
+			logtest.Record{
+				Context:    ctx,
+				Severity:   log.SeverityError,
+				Body:       log.StringValue("plain failure"),
+				Attributes: []log.KeyValue{log.String("error", "plain")},
+			},
+		},
+	}
+
+	got := recorder.Result()
+	logtest.AssertEqual(t, want, got, logtest.Transform(func(r logtest.Record) logtest.Record {
+		r.Timestamp = time.Time{}
+		r.ObservedTimestamp = time.Time{}
+		return r
+	}))
+}
+
+func TestLogger_ErrorFrom(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"})
+
+	ctx := t.Context()
+	logger.ErrorFrom(ctx, codeError{code: "E42"}, "id", 1)
+	logger.ErrorFrom(ctx, nil, "id", 2)
+
+	want := logtest.Recording{
+		logtest.Scope{
+			Name: "test-logger",
+		}: {
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityError,
+				Body:     log.StringValue("failed with E42"),
+				Attributes: []log.KeyValue{
+					log.Int64("id", 1),
+					log.String("error.type", "olog.codeError"),
+				},
+			},
+		},
+	}
+
+	got := recorder.Result()
+	logtest.AssertEqual(t, want, got, logtest.Transform(func(r logtest.Record) logtest.Record {
+		r.Timestamp = time.Time{}
+		r.ObservedTimestamp = time.Time{}
+		return r
+	}))
+}
+
+func TestLogger_ErrorFromNilErrorBody(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger", NilErrorBody: "nil error"})
+
+	ctx := t.Context()
+	logger.ErrorFrom(ctx, nil, "id", 2)
+
+	want := logtest.Recording{
+		logtest.Scope{
+			Name: "test-logger",
+		}: {
+			logtest.Record{
+				Context:    ctx,
+				Severity:   log.SeverityError,
+				Body:       log.StringValue("nil error"),
+				Attributes: []log.KeyValue{log.Int64("id", 2)},
+			},
+		},
+	}
+
+	got := recorder.Result()
+	logtest.AssertEqual(t, want, got, logtest.Transform(func(r logtest.Record) logtest.Record {
+		r.Timestamp = time.Time{}
+		r.ObservedTimestamp = time.Time{}
+		return r
+	}))
+}
+
+func TestLogger_Errors(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"})
+
+	ctx := t.Context()
+	logger.Errors(ctx, "cleanup failed", errors.Join(errors.New("close file"), codeError{code: "E1"}), "job", 7)
+	logger.Errors(ctx, "single failed", errors.New("boom"))
+	logger.Errors(ctx, "no failure", nil)
+
+	want := logtest.Recording{
+		logtest.Scope{
+			Name: "test-logger",
+		}: {
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityError,
+				Body:     log.StringValue("cleanup failed"),
+				Attributes: []log.KeyValue{
+					log.Int64("job", 7),
+					log.String("error", "close file"),
+					log.String("error.type", "*errors.errorString"),
+				},
+			},
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityError,
+				Body:     log.StringValue("cleanup failed"),
+				Attributes: []log.KeyValue{
+					log.Int64("job", 7),
+					log.String("error", "failed with E1"),
+					log.String("error.type", "olog.codeError"),
+				},
+			},
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityError,
+				Body:     log.StringValue("single failed"),
+				Attributes: []log.KeyValue{
+					log.String("error", "boom"),
+					log.String("error.type", "*errors.errorString"),
+				},
+			},
+		},
+	}
+
+	got := recorder.Result()
+	logtest.AssertEqual(t, want, got, logtest.Transform(func(r logtest.Record) logtest.Record {
+		r.Timestamp = time.Time{}
+		r.ObservedTimestamp = time.Time{}
+		return r
+	}))
+}
@@ -0,0 +1,28 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// firstSeen holds the keys of TagFirst for which a log record was emitted.
+var firstSeen sync.Map
+
+// TagFirst returns a new Logger that adds the first_seen=true attribute
+// to the first log record it emits for key. The keys are shared by all
+// loggers in the process, so the attribute is added once per key
+// even if TagFirst is called multiple times, for example in a loop.
+// Records dropped by the filters of the logger do not count.
+func (l *Logger) TagFirst(key string) *Logger {
+	return l.WithContextFunc(func(context.Context) []log.KeyValue {
+		if _, seen := firstSeen.LoadOrStore(key, struct{}{}); seen {
+			return nil
+		}
+		return []log.KeyValue{log.Bool("first_seen", true)}
+	})
+}
@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+)
+
+func TestLogger_TagFirst(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger", MinSeverity: log.SeverityInfo})
+	key := t.Name()
+
+	ctx := t.Context()
+	logger.TagFirst(key).Debug(ctx, "dropped")
+	logger.TagFirst(key).Info(ctx, "first", "n", 1)
+	logger.TagFirst(key).Info(ctx, "second", "n", 2)
+	logger.TagFirst(key+"-other").Info(ctx, "other")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	require.Len(t, records, 3)
+	assert.Equal(t, []log.KeyValue{log.Int("n", 1), log.Bool("first_seen", true)}, records[0].Attributes)
+	assert.Equal(t, []log.KeyValue{log.Int("n", 2)}, records[1].Attributes)
+	assert.Equal(t, []log.KeyValue{log.Bool("first_seen", true)}, records[2].Attributes)
+}
+
+func TestLogger_TagFirstConcurrent(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"}).TagFirst(t.Name())
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			logger.Info(t.Context(), "msg")
+		}()
+	}
+	wg.Wait()
+
+	var tagged int
+	for _, r := range recorder.Result()[logtest.Scope{Name: "test-logger"}] {
+		tagged += len(r.Attributes)
+	}
+	assert.Equal(t, 1, tagged)
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// globalAttrs holds the functions registered with RegisterGlobalAttrs.
+var globalAttrs struct {
+	// mu serializes the registrations.
+	mu sync.Mutex
+	// fns is replaced on each registration, so it can be read without locking.
+	fns atomic.Pointer[[]func(ctx context.Context) []log.KeyValue]
+}
+
+// RegisterGlobalAttrs registers fn adding attributes to the log records of all
+// loggers created with Options.UseGlobalHooks, for example the deployment region.
+// The functions are called in the order they are registered, with the context
+// of each log record. It is safe for concurrent use.
+func RegisterGlobalAttrs(fn func(ctx context.Context) []log.KeyValue) {
+	globalAttrs.mu.Lock()
+	defer globalAttrs.mu.Unlock()
+
+	var fns []func(ctx context.Context) []log.KeyValue
+	if p := globalAttrs.fns.Load(); p != nil {
+		fns = append(fns, *p...)
+	}
+	fns = append(fns, fn)
+	globalAttrs.fns.Store(&fns)
+}
+
+// addGlobalAttributes adds the attributes returned by the functions
+// registered with RegisterGlobalAttrs to the record.
+func addGlobalAttributes(ctx context.Context, record *log.Record) {
+	p := globalAttrs.fns.Load()
+	if p == nil {
+		return
+	}
+	for _, fn := range *p {
+		record.AddAttributes(fn(ctx)...)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+)
+
+type regionKey struct{}
+
+func TestRegisterGlobalAttrs(t *testing.T) {
+	orig := globalAttrs.fns.Load()
+	t.Cleanup(func() { globalAttrs.fns.Store(orig) })
+
+	RegisterGlobalAttrs(func(context.Context) []log.KeyValue {
+		return []log.KeyValue{log.String("deployment.region", "eu-west-1")}
+	})
+	RegisterGlobalAttrs(func(ctx context.Context) []log.KeyValue {
+		if zone, ok := ctx.Value(regionKey{}).(string); ok {
+			return []log.KeyValue{log.String("zone", zone)}
+		}
+		return nil
+	})
+
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger", UseGlobalHooks: true}).With("service", "api")
+	disabled := New(Options{Provider: recorder, Name: "test-logger"})
+
+	ctx := context.WithValue(t.Context(), regionKey{}, "b")
+	logger.Info(ctx, "args", "n", 1)
+	logger.InfoEventAttr(t.Context(), "event", log.Int("n", 2))
+	disabled.Info(ctx, "disabled")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	require.Len(t, records, 3)
+	assert.Equal(t, []log.KeyValue{
+		log.String("service", "api"),
+		log.String("deployment.region", "eu-west-1"),
+		log.String("zone", "b"),
+		log.Int64("n", 1),
+	}, records[0].Attributes)
+	assert.Equal(t, []log.KeyValue{
+		log.String("service", "api"),
+		log.String("deployment.region", "eu-west-1"),
+		log.Int("n", 2),
+	}, records[1].Attributes)
+	assert.Empty(t, records[2].Attributes)
+}
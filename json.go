@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import (
+	"encoding/json"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// jsonRecord is the JSON representation of a log record.
+type jsonRecord struct {
+	Timestamp         string         `json:"timestamp,omitempty"`
+	ObservedTimestamp string         `json:"observed_timestamp,omitempty"`
+	Severity          log.Severity   `json:"severity,omitempty"`
+	SeverityText      string         `json:"severity_text,omitempty"`
+	Body              any            `json:"body,omitempty"`
+	EventName         string         `json:"event_name,omitempty"`
+	Attributes        map[string]any `json:"attributes,omitempty"`
+}
+
+// RecordToJSON returns the JSON encoding of the log record.
+// It is intended for debugging what is passed to exporters.
+//
+// The timestamps are encoded in RFC 3339 format, the severity as its number,
+// and the body and attribute values as the corresponding JSON values.
+// Bytes are encoded as base64 strings. Unset fields are omitted.
+func RecordToJSON(r log.Record) ([]byte, error) {
+	jr := jsonRecord{
+		Timestamp:         formatJSONTime(r.Timestamp()),
+		ObservedTimestamp: formatJSONTime(r.ObservedTimestamp()),
+		Severity:          r.Severity(),
+		SeverityText:      r.SeverityText(),
+		Body:              valueToJSON(r.Body()),
+		EventName:         r.EventName(),
+	}
+	if r.AttributesLen() > 0 {
+		jr.Attributes = make(map[string]any, r.AttributesLen())
+		r.WalkAttributes(func(kv log.KeyValue) bool {
+			jr.Attributes[kv.Key] = valueToJSON(kv.Value)
+			return true
+		})
+	}
+	return json.Marshal(jr)
+}
+
+// formatJSONTime formats t in RFC 3339 format. It returns an empty string for the zero time.
+func formatJSONTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+// keyValuesToJSON encodes the attributes as a JSON object.
+func keyValuesToJSON(kvs []log.KeyValue) (string, error) {
+	b, err := json.Marshal(keyValuesToMap(kvs))
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// keyValuesToMap converts the attributes to a map that can be encoded as JSON.
+func keyValuesToMap(kvs []log.KeyValue) map[string]any {
+	m := make(map[string]any, len(kvs))
+	for _, kv := range kvs {
+		m[kv.Key] = valueToJSON(kv.Value)
+	}
+	return m
+}
+
+// valueToJSON converts v to a value that can be encoded as JSON.
+// Bytes are encoded as base64 strings by encoding/json.
+func valueToJSON(v log.Value) any {
+	switch v.Kind() {
+	case log.KindBool:
+		return v.AsBool()
+	case log.KindFloat64:
+		return v.AsFloat64()
+	case log.KindInt64:
+		return v.AsInt64()
+	case log.KindString:
+		return v.AsString()
+	case log.KindBytes:
+		return v.AsBytes()
+	case log.KindSlice:
+		items := v.AsSlice()
+		s := make([]any, 0, len(items))
+		for _, item := range items {
+			s = append(s, valueToJSON(item))
+		}
+		return s
+	case log.KindMap:
+		return keyValuesToMap(v.AsMap())
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+func TestKeyValuesToJSON(t *testing.T) {
+	got, err := keyValuesToJSON([]log.KeyValue{
+		log.Bool("bool", true),
+		log.Float64("float", 1.5),
+		log.Int64("int", 42),
+		log.String("string", "value"),
+		log.Bytes("bytes", []byte("hi")),
+		log.Slice("slice", log.StringValue("a"), log.Int64Value(1)),
+		log.Map("map", log.String("nested", "x")),
+		{Key: "empty"},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"bool": true,
+		"float": 1.5,
+		"int": 42,
+		"string": "value",
+		"bytes": "aGk=",
+		"slice": ["a", 1],
+		"map": {"nested": "x"},
+		"empty": null
+	}`, got)
+}
+
+func TestRecordToJSON(t *testing.T) {
+	var r log.Record
+	r.SetTimestamp(time.Date(2024, time.March, 5, 14, 30, 0, 123, time.UTC))
+	r.SetSeverity(log.SeverityWarn)
+	r.SetSeverityText("WARN")
+	r.SetBody(log.StringValue("disk almost full"))
+	r.SetEventName("disk.usage")
+	r.AddAttributes(
+		log.Bool("critical", false),
+		log.Float64("usage", 0.95),
+		log.Int("free_mb", 512),
+		log.String("mount", "/data"),
+		log.Bytes("checksum", []byte{0xde, 0xad}),
+		log.Slice("devices", log.StringValue("sda"), log.StringValue("sdb")),
+		log.Map("host", log.String("name", "db-1"), log.Map("labels", log.String("zone", "a"))),
+	)
+
+	got, err := RecordToJSON(r)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"timestamp": "2024-03-05T14:30:00.000000123Z",
+		"severity": 13,
+		"severity_text": "WARN",
+		"body": "disk almost full",
+		"event_name": "disk.usage",
+		"attributes": {
+			"critical": false,
+			"usage": 0.95,
+			"free_mb": 512,
+			"mount": "/data",
+			"checksum": "3q0=",
+			"devices": ["sda", "sdb"],
+			"host": {"name": "db-1", "labels": {"zone": "a"}}
+		}
+	}`, string(got))
+}
+
+func TestRecordToJSONEmpty(t *testing.T) {
+	got, err := RecordToJSON(log.Record{})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(got))
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// KV is a typed attribute, created with the short constructors S, I, B, and F:
+//
+//	logger.InfoKV(ctx, "request handled", olog.S("method", "GET"), olog.I("status", 200))
+type KV = log.KeyValue
+
+// S returns a string attribute.
+func S(key, value string) KV {
+	return log.String(key, value)
+}
+
+// I returns an int attribute.
+func I(key string, value int) KV {
+	return log.Int(key, value)
+}
+
+// B returns a bool attribute.
+func B(key string, value bool) KV {
+	return log.Bool(key, value)
+}
+
+// F returns a float64 attribute.
+func F(key string, value float64) KV {
+	return log.Float64(key, value)
+}
+
+// TraceKV logs a trace message with the typed attributes.
+func (l *Logger) TraceKV(ctx context.Context, msg string, kv ...KV) {
+	l.logAttr(ctx, log.SeverityTrace, msg, kv)
+}
+
+// DebugKV logs a debug message with the typed attributes.
+func (l *Logger) DebugKV(ctx context.Context, msg string, kv ...KV) {
+	l.logAttr(ctx, log.SeverityDebug, msg, kv)
+}
+
+// InfoKV logs an info message with the typed attributes.
+func (l *Logger) InfoKV(ctx context.Context, msg string, kv ...KV) {
+	l.logAttr(ctx, log.SeverityInfo, msg, kv)
+}
+
+// WarnKV logs a warning message with the typed attributes.
+func (l *Logger) WarnKV(ctx context.Context, msg string, kv ...KV) {
+	l.logAttr(ctx, log.SeverityWarn, msg, kv)
+}
+
+// ErrorKV logs an error message with the typed attributes.
+func (l *Logger) ErrorKV(ctx context.Context, msg string, kv ...KV) {
+	l.logAttr(ctx, log.SeverityError, msg, kv)
+}
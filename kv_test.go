@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+)
+
+func TestKVConstructors(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		kv       KV
+		wantKind log.Kind
+		want     log.KeyValue
+	}{
+		{name: "S", kv: S("k", "v"), wantKind: log.KindString, want: log.String("k", "v")},
+		{name: "I", kv: I("k", 1), wantKind: log.KindInt64, want: log.Int64("k", 1)},
+		{name: "B", kv: B("k", true), wantKind: log.KindBool, want: log.Bool("k", true)},
+		{name: "F", kv: F("k", 1.5), wantKind: log.KindFloat64, want: log.Float64("k", 1.5)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantKind, tt.kv.Value.Kind())
+			assert.True(t, tt.want.Equal(tt.kv), "got %v, want %v", tt.kv, tt.want)
+		})
+	}
+}
+
+func TestLogger_KV(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"}).With("service", "api")
+
+	ctx := t.Context()
+	logger.TraceKV(ctx, "trace")
+	logger.DebugKV(ctx, "debug")
+	logger.InfoKV(ctx, "info", S("method", "GET"), I("status", 200), B("cached", false), F("ratio", 0.5))
+	logger.WarnKV(ctx, "warn")
+	logger.ErrorKV(ctx, "error")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	require.Len(t, records, 5)
+	for i, level := range []log.Severity{log.SeverityTrace, log.SeverityDebug, log.SeverityInfo, log.SeverityWarn, log.SeverityError} {
+		assert.Equal(t, level, records[i].Severity)
+	}
+	assert.Equal(t, []log.KeyValue{
+		log.String("service", "api"),
+		log.String("method", "GET"),
+		log.Int("status", 200),
+		log.Bool("cached", false),
+		log.Float64("ratio", 0.5),
+	}, records[2].Attributes)
+}
@@ -0,0 +1,27 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import "go.opentelemetry.io/otel/log"
+
+// LastEmitted returns a copy of the log record most recently emitted by the
+// logger or the loggers sharing its Options, and whether there is one.
+// It always returns false unless Options.TrackLastEmitted is set.
+//
+// It is intended for single-record assertions in unit tests
+// that do not need a full recorder:
+//
+//	logger := olog.New(olog.Options{Provider: noop.NewLoggerProvider(), TrackLastEmitted: true})
+//	process(ctx, logger)
+//	r, ok := logger.LastEmitted()
+func (l *Logger) LastEmitted() (log.Record, bool) {
+	if l.cfg.lastEmitted == nil {
+		return log.Record{}, false
+	}
+	r := l.cfg.lastEmitted.Load()
+	if r == nil {
+		return log.Record{}, false
+	}
+	return r.Clone(), true
+}
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+)
+
+func TestLogger_LastEmitted(t *testing.T) {
+	logger := New(Options{Provider: logtest.NewRecorder(), Name: "test-logger", MinSeverity: log.SeverityInfo, TrackLastEmitted: true})
+
+	_, ok := logger.LastEmitted()
+	assert.False(t, ok, "nothing emitted")
+
+	ctx := t.Context()
+	logger.Info(ctx, "first", "n", 1)
+	logger.With("component", "db").Warn(ctx, "second", "n", 2)
+	logger.Debug(ctx, "dropped")
+
+	r, ok := logger.LastEmitted()
+	require.True(t, ok)
+	assert.Equal(t, log.SeverityWarn, r.Severity())
+	assert.Equal(t, log.StringValue("second"), r.Body())
+	var attrs []log.KeyValue
+	r.WalkAttributes(func(kv log.KeyValue) bool {
+		attrs = append(attrs, kv)
+		return true
+	})
+	assert.Equal(t, []log.KeyValue{log.String("component", "db"), log.Int64("n", 2)}, attrs)
+}
+
+func TestLogger_LastEmitted_Disabled(t *testing.T) {
+	logger := New(Options{Provider: logtest.NewRecorder(), Name: "test-logger"})
+
+	logger.Info(t.Context(), "msg")
+
+	_, ok := logger.LastEmitted()
+	assert.False(t, ok)
+}
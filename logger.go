@@ -5,12 +5,24 @@ package olog // import "github.com/pellared/olog"
 
 import (
 	"context"
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"os"
+	"regexp"
 	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/log"
 	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Options contains configuration options for creating a Logger.
@@ -27,6 +39,290 @@ type Options struct {
 
 	// Attributes are pre-configured attributes that will be included in all log records.
 	Attributes attribute.Set
+
+	// AssertPanics makes Assert panic after emitting the record of a failed assertion.
+	AssertPanics bool
+
+	// NameAsAttr is the attribute name under which the name of the logger,
+	// its instrumentation scope name, is added to all log records,
+	// for example "logger.name". If empty, the name is not added.
+	NameAsAttr string
+
+	// TenantInName makes WithTenant also add the tenant to the instrumentation
+	// scope name, as with Named("tenant." + tenantID), so that the log records
+	// can be filtered per tenant by the scope name.
+	TenantInName bool
+
+	// IncludeHost adds the host.name attribute to all log records.
+	// The hostname is resolved once when the logger is created.
+	IncludeHost bool
+
+	// IncludePID adds the process.pid attribute to all log records.
+	IncludePID bool
+
+	// EmitStartupRecord makes New log an info record noting the creation of
+	// the logger, with the logger.name, logger.version, and logger.min_severity
+	// attributes, to help debugging missing logs. Like other info records,
+	// it is not emitted if MinSeverity is above info.
+	EmitStartupRecord bool
+
+	// UseGlobalHooks adds the attributes returned by the functions registered
+	// with RegisterGlobalAttrs to all log records, after the attributes
+	// added with With and WithAttr and before the attributes of the call.
+	UseGlobalHooks bool
+
+	// IncludeCallerModule adds the caller.module.version attribute to all log records.
+	// It is the version of the module owning the package that called New,
+	// resolved once using the build information of the running binary.
+	// The attribute is omitted if the version cannot be determined.
+	IncludeCallerModule bool
+
+	// MinSeverity is the minimum severity of emitted log records.
+	// Log records with a lower severity are dropped.
+	// If zero, log records of all severities are emitted.
+	MinSeverity log.Severity
+
+	// Sampler decides whether a log record is emitted.
+	// If nil, all log records are emitted.
+	Sampler Sampler
+
+	// FlattenStructArgs makes the key-value methods flatten a struct passed
+	// in place of a key into attributes of its exported fields, see StructAttrs.
+	FlattenStructArgs bool
+
+	// ExpandAttrers makes the key-value methods expand a value implementing Attrer
+	// passed in place of a key into the attributes returned by its LogAttrs method.
+	ExpandAttrers bool
+
+	// ExpandMapArgs makes the key-value methods expand a map[string]any passed
+	// in place of a key into attributes of its entries, sorted by key.
+	ExpandMapArgs bool
+
+	// MaskPatterns are the patterns of secrets masked in the log record body
+	// and string attribute values. Each matching substring is replaced with "***".
+	MaskPatterns []*regexp.Regexp
+
+	// Now returns the timestamp of the log records.
+	// If nil, time.Now is used.
+	Now func() time.Time
+
+	// UseMonotonic keeps the monotonic clock reading of the timestamps.
+	// If false, the timestamps are stripped to the wall clock reading.
+	UseMonotonic bool
+
+	// MaxRecordBytes is the approximate maximum size of a log record in bytes,
+	// counting the body first and then the keys and values of the attributes.
+	// Once it is exceeded, the remaining attributes are dropped
+	// and the log.truncated=true attribute is added.
+	// If zero, the size is not limited.
+	MaxRecordBytes int
+
+	// AttrPrefix is prepended to the keys of all attributes of the log records
+	// when they are emitted, for example "tenant_a." turns "user.id" into
+	// "tenant_a.user.id". The body and event name are not affected.
+	AttrPrefix string
+
+	// ValueTransformers rewrite the values of the attributes of the log records
+	// when they are emitted, for example to hash the values of keys ending
+	// with ".email". Each attribute is passed to the transformers in order, with
+	// its key after KeyAliases and before AttrPrefix are applied, and the value
+	// returned by the first transformer returning true replaces its value.
+	ValueTransformers []func(key string, v log.Value) (log.Value, bool)
+
+	// KeyAliases renames the attributes of the log records when they are emitted,
+	// mapping the legacy keys to the new ones, for example "uid" to "user.id".
+	// The keys are renamed before the other transformations, such as AttrPrefix,
+	// are applied. Nested map keys are not renamed.
+	KeyAliases map[string]string
+
+	// ValidateAttr is called with each attribute of the log records before they are emitted.
+	// The attributes for which it returns an error are dropped and the error
+	// is reported as described in OnError.
+	ValidateAttr func(kv log.KeyValue) error
+
+	// BodyFunc transforms the messages of the log records before they are set
+	// as the bodies, for example to normalize them. It is not applied to events.
+	BodyFunc func(ctx context.Context, body string) string
+
+	// SeverityTextFunc returns the severity text set on the log records of the given severity.
+	// If nil, the severity text is not set. See SyslogSeverityText.
+	SeverityTextFunc func(level log.Severity) string
+
+	// SeverityBucketAttr adds the severity.bucket attribute, a coarse label
+	// of the severity returned by SeverityBucketFunc, to all log records.
+	SeverityBucketAttr bool
+
+	// SeverityBucketFunc returns the coarse label of the given severity
+	// added with SeverityBucketAttr. If nil, SeverityBucket is used.
+	SeverityBucketFunc func(level log.Severity) string
+
+	// SeverityAttrs are the attributes added to the log records with the given severity,
+	// for example to tag error records for routing.
+	SeverityAttrs map[log.Severity][]log.KeyValue
+
+	// OmitEmpty drops the attributes with empty values, empty strings, or empty bytes.
+	// The log record body is never dropped.
+	OmitEmpty bool
+
+	// TimeFormat is the layout used to format time.Time values of key-value arguments,
+	// see time.Layout. If empty, they are logged as Unix time in nanoseconds,
+	// and the zero time.Time, whose Unix time overflows, as an empty value.
+	// time.Duration values are always logged as integer nanoseconds.
+	TimeFormat string
+
+	// RunesAsStrings converts rune values of key-value arguments to single-character
+	// strings. As rune is an alias of int32, all int32 values are converted to
+	// strings, for example int32(65) to "A". If false, they are converted to integers.
+	RunesAsStrings bool
+
+	// MaxValueDepth is the maximum nesting depth of the maps and slices of
+	// key-value arguments that are expanded. Deeper maps and slices are
+	// logged as strings formatted with %v. If zero, the depth is unbounded.
+	MaxValueDepth int
+
+	// ContextKeys are the keys of the context values added to all log records.
+	// Each value is added under the name at the same index in ContextKeyNames
+	// if it is present in the context and not nil.
+	ContextKeys []any
+
+	// ContextKeyNames are the attribute names of the values of ContextKeys.
+	// It must have the same length as ContextKeys.
+	ContextKeyNames []string
+
+	// ErrorCounter is incremented for each emitted log record with error
+	// or higher severity, with the otel.scope.name attribute set to the logger name.
+	// If nil, nothing is counted.
+	ErrorCounter metric.Int64Counter
+
+	// TrackLastEmitted keeps a copy of the most recently emitted log record,
+	// returned by LastEmitted, for quick assertions in tests.
+	// With an asynchronous middleware such as Async, a record is
+	// tracked once it is emitted, not when it is logged.
+	TrackLastEmitted bool
+
+	// WithTraceContext adds the trace_id, span_id, and trace_flags attributes
+	// of the span in the logging context to all log records.
+	// The OpenTelemetry Logs API has no log record setters for the trace context,
+	// which the SDK takes from the context passed to Emit, so the attributes
+	// are for processors and exporters that do not read the context.
+	WithTraceContext bool
+
+	// Middleware wraps the emission of the log records,
+	// the first middleware being the outermost. See NewThrottle.
+	Middleware []Middleware
+
+	// Tap is called with each log record after it is emitted, that is after
+	// it passed through the Middleware. It observes the records, for example
+	// to count them by severity, and cannot drop or modify them.
+	// It is called by the goroutine emitting the record, which is the
+	// background goroutine of an asynchronous middleware such as Async,
+	// so it should return quickly.
+	Tap func(ctx context.Context, r log.Record)
+
+	// OnDrop is called with each log record that is not emitted because of
+	// a filter of the logger, with the reason it is dropped:
+	//   - "min_severity": the severity is below Options.MinSeverity or
+	//     the minimum severity set with WithMinSeverity
+	//   - "sampled": the sampler, or the ratio of a method such as
+	//     InfoSampled, rejected the record
+	//
+	// The record is dropped before the attributes added at emission,
+	// such as the context attributes, are set. It is called synchronously,
+	// so it should return quickly.
+	OnDrop func(ctx context.Context, r log.Record, reason string)
+
+	// OnError is called with the errors of invalid configuration or usage.
+	// If nil, the errors are passed to the OpenTelemetry global error handler.
+	OnError func(err error)
+
+	// Strict makes the logger panic on the errors instead of calling OnError.
+	Strict bool
+
+	// WithCacheSize is the maximum number of entries of the cache of the attributes
+	// converted from the arguments of With. Repeated With calls with the same
+	// string, bool, int, int64, and float64 arguments share the cached attributes.
+	// The cache is emptied when it is full. If zero, With does not use a cache.
+	WithCacheSize int
+
+	// DeprecatedEvents maps deprecated event names to their replacements.
+	// The first time a deprecated event is emitted, a Warn record noting
+	// the replacement is emitted in addition to the event.
+	DeprecatedEvents map[string]string
+
+	// NilErrorBody is the body logged by ErrorFrom for a nil error.
+	// If empty, ErrorFrom logs nothing for a nil error.
+	NilErrorBody string
+
+	// CollapseWithAttrs is the attribute name under which the attributes added
+	// with With and WithAttr are emitted as a single JSON-encoded string.
+	// KeyAliases, ValidateAttr, and OmitEmpty are applied to the attributes
+	// before they are encoded. If empty, the attributes are emitted individually.
+	CollapseWithAttrs string
+}
+
+// Sampler decides whether a log record with the given severity and event name is emitted.
+// The eventName is empty for log records that are not events.
+type Sampler func(ctx context.Context, level log.Severity, eventName string) bool
+
+// config holds the Options-derived state shared by a Logger and the loggers derived from it.
+type config struct {
+	provider     log.LoggerProvider
+	loggerOpts   []log.LoggerOption
+	assertPanics bool
+	nameKey      string
+	tenantName   bool
+	filters      atomic.Pointer[filters]
+	collapseKey  string
+	flattenArgs  bool
+	expandArgs   bool
+	expandMaps   bool
+	omitEmpty    bool
+	maxBytes     int
+	validateAttr func(kv log.KeyValue) error
+	attrPrefix   string
+	keyAliases   map[string]string
+	valueFuncs   []func(key string, v log.Value) (log.Value, bool)
+	monotonic    bool
+	levelAttrs   map[log.Severity][]log.KeyValue
+	levelText    func(level log.Severity) string
+	bucketFunc   func(level log.Severity) string
+	bodyFunc     func(ctx context.Context, body string) string
+	contextKeys  []any
+	contextNames []string
+	globalHooks  bool
+	tap          func(ctx context.Context, r log.Record)
+	onDrop       func(ctx context.Context, r log.Record, reason string)
+	middleware   []Middleware
+	errCounter   metric.Int64Counter
+	lastEmitted  *atomic.Pointer[log.Record]
+	traceContext bool
+	onError      func(err error)
+	strict       bool
+	nilErrorBody string
+	deprecations *deprecations
+	withCache    *withCache
+	counts       sync.Map // map[string]*atomic.Int64 of Count
+	// processAttrs are the host and process attributes resolved at creation.
+	processAttrs []log.KeyValue
+}
+
+// osHostname is used to resolve the host name. It is a variable for testing.
+var osHostname = os.Hostname
+
+// processAttributes returns the host and process attributes requested by options.
+func processAttributes(options Options) []log.KeyValue {
+	var attrs []log.KeyValue
+	if options.IncludeHost {
+		host, err := osHostname()
+		if err != nil || host == "" {
+			host = "unknown"
+		}
+		attrs = append(attrs, log.String("host.name", host))
+	}
+	if options.IncludePID {
+		attrs = append(attrs, log.Int("process.pid", os.Getpid()))
+	}
+	return attrs
 }
 
 // Logger provides an ergonomic frontend API for OpenTelemetry structured logging.
@@ -46,6 +342,40 @@ type Options struct {
 // pre-configured loggers.
 type Logger struct {
 	log.Logger
+	cfg         *config
+	attrs       *attrNode
+	baggageKeys []string
+	ctxFuncs    []func(ctx context.Context) []log.KeyValue
+	// namespace is the prefix of the keys of added attributes and event names.
+	namespace string
+	// name is the instrumentation scope name of the underlying log.Logger.
+	name string
+	// clock returns the timestamps of the log records. If nil, time.Now is used.
+	clock func() time.Time
+	// minSeverity is the minimum severity set with WithMinSeverity.
+	minSeverity log.Severity
+	// sampler is the sampler set with WithSampler.
+	// It is used instead of Options.Sampler if ownSampler is true.
+	sampler    Sampler
+	ownSampler bool
+	// emitFn emits the log records through Options.Middleware and outer.
+	// It is nil if there is no middleware.
+	emitFn EmitFunc
+	// outer are the middleware wrapping the emission of the logger, such as the
+	// one of Capture, which are applied again by Named. See withOuter.
+	outer []Middleware
+	// conv converts the values of key-value arguments.
+	conv converter
+	// severityAttrs are the attributes added with WithAttrFromSeverity.
+	severityAttrs []severityAttrs
+	// slowThreshold is the threshold set with WithSlowWarning.
+	slowThreshold time.Duration
+}
+
+// severityAttrs are attributes added to the log records with a severity
+// of at least min.
+type severityAttrs struct {
+	min   log.Severity
 	attrs []log.KeyValue
 }
 
@@ -123,9 +453,13 @@ func New(options Options) *Logger {
 	}
 
 	// Use caller's package name if Name is not provided
+	var callerPkg string
+	if options.Name == "" || options.IncludeCallerModule {
+		callerPkg = getCallerPackage()
+	}
 	name := options.Name
 	if name == "" {
-		name = getCallerPackage()
+		name = callerPkg
 	}
 
 	// Create logger options
@@ -140,8 +474,134 @@ func New(options Options) *Logger {
 
 	// Create the underlying log.Logger
 	otelLogger := provider.Logger(name, loggerOptions...)
-	return &Logger{
+	cfg := &config{
+		provider:     provider,
+		loggerOpts:   loggerOptions,
+		assertPanics: options.AssertPanics,
+		nameKey:      options.NameAsAttr,
+		tenantName:   options.TenantInName,
+		collapseKey:  options.CollapseWithAttrs,
+		flattenArgs:  options.FlattenStructArgs,
+		expandArgs:   options.ExpandAttrers,
+		expandMaps:   options.ExpandMapArgs,
+		omitEmpty:    options.OmitEmpty,
+		maxBytes:     options.MaxRecordBytes,
+		validateAttr: options.ValidateAttr,
+		attrPrefix:   options.AttrPrefix,
+		keyAliases:   maps.Clone(options.KeyAliases),
+		valueFuncs:   slices.Clone(options.ValueTransformers),
+		monotonic:    options.UseMonotonic,
+		levelAttrs:   maps.Clone(options.SeverityAttrs),
+		levelText:    options.SeverityTextFunc,
+		bodyFunc:     options.BodyFunc,
+		contextKeys:  slices.Clone(options.ContextKeys),
+		contextNames: slices.Clone(options.ContextKeyNames),
+		globalHooks:  options.UseGlobalHooks,
+		tap:          options.Tap,
+		onDrop:       options.OnDrop,
+		middleware:   slices.Clone(options.Middleware),
+		errCounter:   options.ErrorCounter,
+		traceContext: options.WithTraceContext,
+		onError:      options.OnError,
+		strict:       options.Strict,
+		nilErrorBody: options.NilErrorBody,
+		deprecations: newDeprecations(options.DeprecatedEvents),
+		withCache:    newWithCache(options.WithCacheSize),
+		processAttrs: processAttributes(options),
+	}
+	cfg.filters.Store(newFilters(options))
+	if options.TrackLastEmitted {
+		cfg.lastEmitted = new(atomic.Pointer[log.Record])
+	}
+	if options.IncludeCallerModule {
+		if version := moduleVersion(callerPkg); version != "" {
+			cfg.processAttrs = append(cfg.processAttrs, log.String("caller.module.version", version))
+		}
+	}
+	if options.SeverityBucketAttr {
+		cfg.bucketFunc = options.SeverityBucketFunc
+		if cfg.bucketFunc == nil {
+			cfg.bucketFunc = SeverityBucket
+		}
+	}
+	if len(cfg.contextKeys) != len(cfg.contextNames) {
+		cfg.handleError(fmt.Errorf("olog: %d ContextKeys and %d ContextKeyNames, extra entries are ignored",
+			len(cfg.contextKeys), len(cfg.contextNames)))
+		n := min(len(cfg.contextKeys), len(cfg.contextNames))
+		cfg.contextKeys, cfg.contextNames = cfg.contextKeys[:n], cfg.contextNames[:n]
+	}
+
+	logger := &Logger{
 		Logger: otelLogger,
+		cfg:    cfg,
+		name:   name,
+		clock:  options.Now,
+		emitFn: chainMiddleware(cfg, otelLogger, name),
+		conv: converter{
+			timeFormat:     options.TimeFormat,
+			runesAsStrings: options.RunesAsStrings,
+			maxDepth:       options.MaxValueDepth,
+		},
+	}
+	if options.EmitStartupRecord {
+		logger.logStartup(options)
+	}
+	return logger
+}
+
+// startupMsg is the body of the log record emitted for Options.EmitStartupRecord.
+const startupMsg = "logger created"
+
+// logStartup logs the info record of Options.EmitStartupRecord.
+func (l *Logger) logStartup(options Options) {
+	attrs := []log.KeyValue{log.String("logger.name", l.name)}
+	if options.Version != "" {
+		attrs = append(attrs, log.String("logger.version", options.Version))
+	}
+	level := max(options.MinSeverity, log.SeverityTrace1)
+	attrs = append(attrs, log.String("logger.min_severity", level.String()))
+	l.logAttr(context.Background(), log.SeverityInfo, startupMsg, attrs)
+}
+
+// handleError panics with err in strict mode, otherwise it passes err to Options.OnError
+// or the OpenTelemetry global error handler.
+func (c *config) handleError(err error) {
+	if c.strict {
+		panic(err)
+	}
+	if c.onError != nil {
+		c.onError(err)
+		return
+	}
+	otel.Handle(err)
+}
+
+// Flush flushes the log records buffered by the LoggerProvider the logger was created with.
+// It does nothing if the provider does not support flushing,
+// which is determined by whether it has a ForceFlush(context.Context) error method.
+func (l *Logger) Flush(ctx context.Context) error {
+	if f, ok := l.cfg.provider.(interface {
+		ForceFlush(context.Context) error
+	}); ok {
+		return f.ForceFlush(ctx)
+	}
+	return nil
+}
+
+// FlushTimeout flushes like Flush but returns context.DeadlineExceeded
+// if flushing does not complete within d, even if the provider
+// does not return when its context is done.
+func (l *Logger) FlushTimeout(d time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- l.Flush(ctx) }()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
 	}
 }
 
@@ -180,6 +640,27 @@ func (l *Logger) ErrorEnabled(ctx context.Context) bool {
 	})
 }
 
+// EnabledAny reports whether the logger emits log records of any of the given severities.
+func (l *Logger) EnabledAny(ctx context.Context, levels ...log.Severity) bool {
+	for _, level := range levels {
+		if l.Enabled(ctx, log.EnabledParameters{Severity: level}) {
+			return true
+		}
+	}
+	return false
+}
+
+// WillEmit reports whether a log record with the given severity would be emitted.
+// Unlike InfoEnabled and the other Enabled methods, it also applies
+// Options.MinSeverity and Options.Sampler.
+// The result is best-effort when the sampler is probabilistic,
+// as the sampler is consulted again when the record is emitted.
+func (l *Logger) WillEmit(ctx context.Context, level log.Severity) bool {
+	return l.allowed(ctx, level, "") && l.Enabled(ctx, log.EnabledParameters{
+		Severity: level,
+	})
+}
+
 // TraceEventEnabled reports whether the logger emits trace-level event log records for the specified event name.
 func (l *Logger) TraceEventEnabled(ctx context.Context, eventName string) bool {
 	return l.Enabled(ctx, log.EnabledParameters{
@@ -310,6 +791,71 @@ func (l *Logger) LogAttr(ctx context.Context, level log.Severity, msg string, at
 	l.logAttr(ctx, level, msg, attrs)
 }
 
+// LogWithTimes logs a message at the specified level with the provided attributes
+// and the explicitly set timestamp and observed timestamp.
+// It is intended for tools replaying or backfilling historical log records.
+func (l *Logger) LogWithTimes(ctx context.Context, level log.Severity, msg string, ts, observed time.Time, attrs ...log.KeyValue) {
+	var record log.Record
+	record.SetBody(l.body(ctx, msg))
+	record.SetTimestamp(ts)
+	record.SetObservedTimestamp(observed)
+	record.SetSeverity(level)
+
+	l.addKeyValueAttributes(ctx, &record, attrs)
+	l.emit(ctx, record)
+}
+
+// TraceAttrAt logs a trace message with the provided attributes and the timestamp ts.
+// The observed timestamp is the current time.
+func (l *Logger) TraceAttrAt(ctx context.Context, ts time.Time, msg string, attrs ...log.KeyValue) {
+	l.LogWithTimes(ctx, log.SeverityTrace, msg, ts, l.now(), attrs...)
+}
+
+// DebugAttrAt logs a debug message with the provided attributes and the timestamp ts.
+// The observed timestamp is the current time.
+func (l *Logger) DebugAttrAt(ctx context.Context, ts time.Time, msg string, attrs ...log.KeyValue) {
+	l.LogWithTimes(ctx, log.SeverityDebug, msg, ts, l.now(), attrs...)
+}
+
+// InfoAttrAt logs an info message with the provided attributes and the timestamp ts.
+// The observed timestamp is the current time.
+func (l *Logger) InfoAttrAt(ctx context.Context, ts time.Time, msg string, attrs ...log.KeyValue) {
+	l.LogWithTimes(ctx, log.SeverityInfo, msg, ts, l.now(), attrs...)
+}
+
+// WarnAttrAt logs a warning message with the provided attributes and the timestamp ts.
+// The observed timestamp is the current time.
+func (l *Logger) WarnAttrAt(ctx context.Context, ts time.Time, msg string, attrs ...log.KeyValue) {
+	l.LogWithTimes(ctx, log.SeverityWarn, msg, ts, l.now(), attrs...)
+}
+
+// ErrorAttrAt logs an error message with the provided attributes and the timestamp ts.
+// The observed timestamp is the current time.
+func (l *Logger) ErrorAttrAt(ctx context.Context, ts time.Time, msg string, attrs ...log.KeyValue) {
+	l.LogWithTimes(ctx, log.SeverityError, msg, ts, l.now(), attrs...)
+}
+
+// TraceLazy logs a trace message with the attributes returned by fn.
+// The fn is called only if a trace-level log record would be emitted, see WillEmit.
+func (l *Logger) TraceLazy(ctx context.Context, fn func() (string, []log.KeyValue)) {
+	l.logLazy(ctx, log.SeverityTrace, fn)
+}
+
+// DebugLazy logs a debug message with the attributes returned by fn.
+// The fn is called only if a debug-level log record would be emitted, see WillEmit.
+func (l *Logger) DebugLazy(ctx context.Context, fn func() (string, []log.KeyValue)) {
+	l.logLazy(ctx, log.SeverityDebug, fn)
+}
+
+// logLazy logs the message and attributes returned by fn if the level would be emitted.
+func (l *Logger) logLazy(ctx context.Context, level log.Severity, fn func() (string, []log.KeyValue)) {
+	if !l.WillEmit(ctx, level) {
+		return
+	}
+	msg, attrs := fn()
+	l.emitChecked(ctx, l.attrRecord(ctx, level, msg, attrs))
+}
+
 // TraceEventAttr logs a trace-level event with the specified name and the provided attributes.
 func (l *Logger) TraceEventAttr(ctx context.Context, name string, attrs ...log.KeyValue) {
 	l.logEventAttr(ctx, log.SeverityTrace, name, attrs)
@@ -340,125 +886,699 @@ func (l *Logger) EventAttr(ctx context.Context, level log.Severity, name string,
 	l.logEventAttr(ctx, level, name, attrs)
 }
 
+// TraceEventMsg logs a trace-level event with the specified name that also has msg
+// as its body and the provided attributes.
+func (l *Logger) TraceEventMsg(ctx context.Context, name, msg string, attrs ...log.KeyValue) {
+	l.logEventMsg(ctx, log.SeverityTrace, name, msg, attrs)
+}
+
+// DebugEventMsg logs a debug-level event with the specified name that also has msg
+// as its body and the provided attributes.
+func (l *Logger) DebugEventMsg(ctx context.Context, name, msg string, attrs ...log.KeyValue) {
+	l.logEventMsg(ctx, log.SeverityDebug, name, msg, attrs)
+}
+
+// InfoEventMsg logs an info-level event with the specified name that also has msg
+// as its body and the provided attributes.
+func (l *Logger) InfoEventMsg(ctx context.Context, name, msg string, attrs ...log.KeyValue) {
+	l.logEventMsg(ctx, log.SeverityInfo, name, msg, attrs)
+}
+
+// WarnEventMsg logs a warn-level event with the specified name that also has msg
+// as its body and the provided attributes.
+func (l *Logger) WarnEventMsg(ctx context.Context, name, msg string, attrs ...log.KeyValue) {
+	l.logEventMsg(ctx, log.SeverityWarn, name, msg, attrs)
+}
+
+// ErrorEventMsg logs an error-level event with the specified name that also has msg
+// as its body and the provided attributes.
+func (l *Logger) ErrorEventMsg(ctx context.Context, name, msg string, attrs ...log.KeyValue) {
+	l.logEventMsg(ctx, log.SeverityError, name, msg, attrs)
+}
+
+// Assert logs an error message with optional key-value pairs and an assertion=failed
+// attribute when cond is false. It does nothing when cond is true.
+// If Options.AssertPanics is set, a failed assertion panics after the record is emitted.
+func (l *Logger) Assert(ctx context.Context, cond bool, msg string, args ...any) {
+	if cond {
+		return
+	}
+
+	l.logExtra(ctx, log.SeverityError, msg, args, log.String("assertion", "failed"))
+
+	if l.cfg.assertPanics {
+		panic("olog: assertion failed: " + msg)
+	}
+}
+
 // WithAttr returns a new Logger that includes the given attributes in all log records.
 func (l *Logger) WithAttr(attrs ...log.KeyValue) *Logger {
-	// Combine existing attrs with new attrs
-	combinedAttrs := make([]log.KeyValue, 0, len(l.attrs)+len(attrs))
-	combinedAttrs = append(combinedAttrs, l.attrs...)
-	combinedAttrs = append(combinedAttrs, attrs...)
+	if l.IsNoop() {
+		return nopLogger
+	}
+	c := l.clone()
+	c.attrs = newAttrNode(l.attrs, l.appendNamespaced(make([]log.KeyValue, 0, len(attrs)), attrs))
+	return c
+}
+
+// WithAttrFromSeverity returns a new Logger that includes the given attributes
+// only in the log records with a severity of at least min, for example
+// to add heavy debugging context only to errors:
+//
+//	logger.WithAttrFromSeverity(log.SeverityError, log.String("request.body", body))
+//
+// The attributes are added after the attributes of the call.
+func (l *Logger) WithAttrFromSeverity(min log.Severity, attrs ...log.KeyValue) *Logger {
+	if l.IsNoop() {
+		return nopLogger
+	}
+	c := l.clone()
+	c.severityAttrs = append(slices.Clip(l.severityAttrs), severityAttrs{
+		min:   min,
+		attrs: l.appendNamespaced(make([]log.KeyValue, 0, len(attrs)), attrs),
+	})
+	return c
+}
+
+// WithAttrsLazy returns a new Logger that includes the attributes returned by fn
+// in all log records. fn is called once, when the first log record of the logger
+// or a logger derived from it is logged, and not at all if nothing is logged.
+// It is intended for attributes that are expensive to compute.
+func (l *Logger) WithAttrsLazy(fn func() []log.KeyValue) *Logger {
+	if l.IsNoop() {
+		return nopLogger
+	}
+	c := l.clone()
+	c.attrs = newLazyAttrNode(l.attrs, fn, l.namespace)
+	return c
+}
+
+// WithLogValuer returns a new Logger that includes the attribute with the given
+// key in all log records. Unlike with WithAttr, its value is not fixed when the
+// logger is created: v is resolved each time a log record is emitted, so that
+// the records reflect the current state, for example:
+//
+//	logger = logger.WithLogValuer("queue.size", olog.LogValuerFunc(func() log.Value {
+//		return log.IntValue(queue.Len())
+//	}))
+//
+// v must be safe for concurrent use if the logger is used concurrently.
+func (l *Logger) WithLogValuer(key string, v LogValuer) *Logger {
+	if l.IsNoop() {
+		return nopLogger
+	}
+	c := l.clone()
+	c.attrs = newValuerAttrNode(l.attrs, l.namespace+key, v)
+	return c
+}
 
-	return &Logger{
-		Logger: l.Logger,
-		attrs:  combinedAttrs,
+// Exemplar returns a new Logger that includes the attributes returned by attrs
+// in all log records if selected is true, and the logger itself otherwise.
+// Like WithAttrsLazy, attrs is called once, when the first log record is logged,
+// and never if selected is false. It attaches expensive, high-cardinality
+// context, such as a full request dump, to a sampled subset of operations:
+//
+//	l := logger.Exemplar(ctx, rand.IntN(100) == 0, func() []log.KeyValue {
+//		return []log.KeyValue{log.String("request.dump", dump(req))}
+//	})
+func (l *Logger) Exemplar(_ context.Context, selected bool, attrs func() []log.KeyValue) *Logger {
+	if !selected {
+		return l
 	}
+	return l.WithAttrsLazy(attrs)
 }
 
 // With returns a new Logger that includes the given attributes in all log records.
 func (l *Logger) With(args ...any) *Logger {
-	// Convert args to KeyValue attributes
-	newAttrs := convertArgsToKeyValues(args)
+	if l.IsNoop() {
+		return nopLogger
+	}
+	c := l.clone()
+	c.attrs = newAttrNode(l.attrs, l.withKeyValues(args))
+	return c
+}
+
+// WithNamespace returns a new Logger that prefixes the keys of attributes added
+// afterwards, with With, WithAttr, or when logging, and the names of logged events
+// with name followed by a dot.
+// Attributes added before are not affected. Namespaces are nested when chained:
+//
+//	logger.WithNamespace("db").WithNamespace("pool").InfoEvent(ctx, "exhausted", "size", 10)
+//	// Logs the "db.pool.exhausted" event with the "db.pool.size" attribute.
+func (l *Logger) WithNamespace(name string) *Logger {
+	c := l.clone()
+	c.namespace = l.namespace + name + "."
+	return c
+}
+
+// WithTenant returns a new Logger that includes the tenant.id attribute
+// in all log records. The key is not prefixed with the namespace.
+// If Options.TenantInName is set, the tenant is also added to the instrumentation
+// scope name, for example "a.tenant.acme" for WithTenant("acme") on a logger named "a".
+func (l *Logger) WithTenant(tenantID string) *Logger {
+	if l.IsNoop() {
+		return nopLogger
+	}
+	var c *Logger
+	if l.cfg.tenantName {
+		c = l.Named("tenant." + tenantID)
+	} else {
+		c = l.clone()
+	}
+	c.attrs = newAttrNode(l.attrs, []log.KeyValue{log.String("tenant.id", tenantID)})
+	return c
+}
 
-	// Combine existing attrs with new attrs
-	combinedAttrs := make([]log.KeyValue, 0, len(l.attrs)+len(newAttrs))
-	combinedAttrs = append(combinedAttrs, l.attrs...)
-	combinedAttrs = append(combinedAttrs, newAttrs...)
+// Named returns a new Logger whose instrumentation scope name is the current
+// name followed by a dot and suffix, for example "a.b" for Named("b") on
+// a logger named "a". The underlying logger is obtained from the provider
+// with the same version and instrumentation attributes.
+// The attributes and namespace of the logger are kept.
+func (l *Logger) Named(suffix string) *Logger {
+	c := l.clone()
+	c.name = l.name + "." + suffix
+	if l.cfg.provider == nil {
+		// The logger is created by Wrap.
+		return c
+	}
+	c.Logger = l.cfg.provider.Logger(c.name, l.cfg.loggerOpts...)
+	c.emitFn = chainMiddleware(l.cfg, c.Logger, c.name)
+	for _, mw := range l.outer {
+		c.emitFn = mw(c.emitFunc())
+	}
+	return c
+}
 
-	return &Logger{
-		Logger: l.Logger,
-		attrs:  combinedAttrs,
+// appendNamespaced appends attrs with keys prefixed with the namespace to dst.
+func (l *Logger) appendNamespaced(dst, attrs []log.KeyValue) []log.KeyValue {
+	if l.namespace == "" {
+		return append(dst, attrs...)
+	}
+	for _, kv := range attrs {
+		kv.Key = l.namespace + kv.Key
+		dst = append(dst, kv)
 	}
+	return dst
+}
+
+// WithTraceID returns a new Logger that includes the trace_id attribute in all log records.
+// It is intended for manual correlation when no span is available in the context.
+func (l *Logger) WithTraceID(id string) *Logger {
+	return l.WithAttr(log.String("trace_id", id))
+}
+
+// WithSpanID returns a new Logger that includes the span_id attribute in all log records.
+// It is intended for manual correlation when no span is available in the context.
+func (l *Logger) WithSpanID(id string) *Logger {
+	return l.WithAttr(log.String("span_id", id))
+}
+
+// WithSpanContext returns a new Logger that includes the trace_id, span_id,
+// and trace_flags attributes of sc in all log records.
+// It is intended for span contexts not stored in the context, for example
+// reconstructed from headers. Nothing is added if sc is invalid.
+func (l *Logger) WithSpanContext(sc trace.SpanContext) *Logger {
+	if !sc.IsValid() {
+		return l.clone()
+	}
+	return l.WithAttr(spanContextAttrs(sc)...)
+}
+
+// spanContextAttrs returns the trace_id, span_id, and trace_flags attributes of sc.
+func spanContextAttrs(sc trace.SpanContext) []log.KeyValue {
+	return []log.KeyValue{
+		log.String("trace_id", sc.TraceID().String()),
+		log.String("span_id", sc.SpanID().String()),
+		log.String("trace_flags", sc.TraceFlags().String()),
+	}
+}
+
+// AttrFingerprint returns a hash of the attributes added with With and WithAttr.
+// The hash does not depend on the order of the attributes,
+// so loggers with the same attributes have the same fingerprint.
+// The attributes added with WithAttrsLazy and WithLogValuer are not hashed,
+// so that their functions are not called.
+func (l *Logger) AttrFingerprint() uint64 {
+	var sum uint64
+	h := fnv.New64a()
+	for n := l.attrs; n != nil; n = n.parent {
+		if n.lazy != nil || n.valuer != nil {
+			continue
+		}
+		for _, kv := range n.attrs {
+			h.Reset()
+			_, _ = h.Write([]byte(kv.Key))
+			_, _ = h.Write([]byte{0, byte(kv.Value.Kind())})
+			_, _ = h.Write([]byte(kv.Value.String()))
+			// Summing the hashes makes the fingerprint order-independent.
+			sum += h.Sum64()
+		}
+	}
+	return sum
+}
+
+// WithBaggageKeys returns a new Logger that includes the named baggage members
+// from the context in all log records.
+// Each member is added at emit time under the key prefixed with "baggage.".
+// Members missing from the baggage are skipped.
+func (l *Logger) WithBaggageKeys(keys ...string) *Logger {
+	combinedKeys := make([]string, 0, len(l.baggageKeys)+len(keys))
+	combinedKeys = append(combinedKeys, l.baggageKeys...)
+	combinedKeys = append(combinedKeys, keys...)
+
+	c := l.clone()
+	c.baggageKeys = combinedKeys
+	return c
+}
+
+// WithContextFunc returns a new Logger that includes the attributes returned by fn
+// for the logging context in all log records.
+// The functions added by chained calls are all called in order.
+func (l *Logger) WithContextFunc(fn func(ctx context.Context) []log.KeyValue) *Logger {
+	combinedFuncs := make([]func(ctx context.Context) []log.KeyValue, 0, len(l.ctxFuncs)+1)
+	combinedFuncs = append(combinedFuncs, l.ctxFuncs...)
+	combinedFuncs = append(combinedFuncs, fn)
+
+	c := l.clone()
+	c.ctxFuncs = combinedFuncs
+	return c
+}
+
+// WithMinSeverity returns a new Logger that drops log records with a severity
+// lower than level. It replaces the minimum severity set on the logger it is
+// derived from, so a child can be more or less verbose than its parent,
+// but it never emits log records dropped by Options.MinSeverity.
+func (l *Logger) WithMinSeverity(level log.Severity) *Logger {
+	c := l.clone()
+	c.minSeverity = level
+	return c
+}
+
+// WithSampler returns a new Logger that uses s instead of the sampler
+// of the logger it is derived from, which is Options.Sampler unless replaced.
+// If s is nil, no log records are dropped by sampling.
+func (l *Logger) WithSampler(s Sampler) *Logger {
+	c := l.clone()
+	c.sampler, c.ownSampler = s, true
+	return c
+}
+
+// WithMaxDepth returns a new Logger that expands the maps and slices of
+// key-value arguments up to n levels deep, see Options.MaxValueDepth.
+// If n is zero, the depth is unbounded.
+func (l *Logger) WithMaxDepth(n int) *Logger {
+	c := l.clone()
+	c.conv.maxDepth = n
+	return c
+}
+
+// WithClock returns a new Logger that uses fn to get the timestamps of log records,
+// for example a fixed clock in tests.
+func (l *Logger) WithClock(fn func() time.Time) *Logger {
+	c := l.clone()
+	c.clock = fn
+	return c
+}
+
+// now returns the timestamp of a log record.
+func (l *Logger) now() time.Time {
+	t := l.clockNow()
+	if !l.cfg.monotonic {
+		// Round(0) strips the monotonic clock reading.
+		t = t.Round(0)
+	}
+	return t
+}
+
+// clockNow returns the time of the clock set with Options.Now or WithClock,
+// or time.Now, with the monotonic clock reading, if none is set.
+func (l *Logger) clockNow() time.Time {
+	if l.clock == nil {
+		return time.Now()
+	}
+	return l.clock()
+}
+
+// clone returns a shallow copy of the logger.
+func (l *Logger) clone() *Logger {
+	c := *l
+	return &c
 }
 
 // log is the internal logging method that handles the common logging logic.
 func (l *Logger) log(ctx context.Context, level log.Severity, msg string, args []any) {
 	var record log.Record
-	record.SetBody(log.StringValue(msg))
-	record.SetTimestamp(time.Now())
+	record.SetBody(l.body(ctx, msg))
+	record.SetTimestamp(l.now())
+	record.SetSeverity(level)
+
+	l.addAttributes(ctx, &record, args)
+	l.emit(ctx, record)
+}
+
+// logExtra logs a message with key-value pairs followed by extra attributes,
+// such as the assertion=failed marker of Assert. The keys of the extra
+// attributes are fixed and are not prefixed with the namespace.
+func (l *Logger) logExtra(ctx context.Context, level log.Severity, msg string, args []any, extra ...log.KeyValue) {
+	var record log.Record
+	record.SetBody(l.body(ctx, msg))
+	record.SetTimestamp(l.now())
 	record.SetSeverity(level)
 
-	l.addAttributes(&record, args)
-	l.Emit(ctx, record)
+	l.addAttributes(ctx, &record, args)
+	record.AddAttributes(extra...)
+	l.emit(ctx, record)
+}
+
+// body returns the record body of the message with Options.BodyFunc applied.
+func (l *Logger) body(ctx context.Context, msg string) log.Value {
+	if l.cfg.bodyFunc != nil {
+		msg = l.cfg.bodyFunc(ctx, msg)
+	}
+	return log.StringValue(msg)
 }
 
 // addAttributes adds key-value pairs to the record.
 // It supports the alternating key-value syntax like slog.
-func (l *Logger) addAttributes(record *log.Record, args []any) {
+func (l *Logger) addAttributes(ctx context.Context, record *log.Record, args []any) {
 	// Add pre-configured attributes first
-	record.AddAttributes(l.attrs...)
+	l.addLoggerAttributes(ctx, record)
 	// Then add call-specific attributes
-	addArgsAsAttributes(record, args)
+	l.addArgsAsAttributes(record, args)
 }
 
 // convertArgsToKeyValues converts alternating key-value arguments to log.KeyValue slice.
-func convertArgsToKeyValues(args []any) []log.KeyValue {
-	keyValues := make([]log.KeyValue, 0, len(args)/2+1)
-	for i := 0; i < len(args); i += 2 {
-		if i+1 >= len(args) {
-			// Odd number of arguments, add the key with empty value
-			if key, ok := args[i].(string); ok {
-				keyValues = append(keyValues, log.String(key, ""))
-			}
-			break
+// If Options.ExpandAttrers is set, an Attrer in a key position is expanded
+// into its attributes. If Options.ExpandMapArgs is set, a map[string]any
+// in a key position is expanded into attributes of its entries.
+// If Options.FlattenStructArgs is set, a struct in a key position
+// is flattened into attributes using StructAttrs.
+func (l *Logger) convertArgsToKeyValues(args []any) []log.KeyValue {
+	if len(args) == 0 {
+		return nil
+	}
+	// Fast path for the common single key-value pair.
+	if len(args) == 2 {
+		if key, ok := args[0].(string); ok {
+			return []log.KeyValue{{Key: key, Value: l.conv.convert(args[1])}}
 		}
+	}
+	return l.appendArgsAsKeyValues(make([]log.KeyValue, 0, len(args)/2+1), args)
+}
 
+// appendArgsAsKeyValues appends the attributes converted from alternating
+// key-value arguments to keyValues.
+func (l *Logger) appendArgsAsKeyValues(keyValues []log.KeyValue, args []any) []log.KeyValue {
+	for i := 0; i < len(args); {
 		key, ok := args[i].(string)
 		if !ok {
+			if a, ok := args[i].(Attrer); ok && l.cfg.expandArgs {
+				keyValues = append(keyValues, a.LogAttrs()...)
+				i++
+				continue
+			}
+			if m, ok := args[i].(map[string]any); ok && l.cfg.expandMaps {
+				keyValues = l.appendMapAsKeyValues(keyValues, m)
+				i++
+				continue
+			}
+			if l.cfg.flattenArgs && isStruct(args[i]) {
+				keyValues = append(keyValues, l.conv.structAttrs(args[i])...)
+				i++
+				continue
+			}
+			i += 2
 			continue
 		}
 
+		if i+1 >= len(args) {
+			// Odd number of arguments, add the key with empty value
+			keyValues = append(keyValues, log.String(key, ""))
+			break
+		}
+
 		value := args[i+1]
 		kv := log.KeyValue{
 			Key:   key,
-			Value: convertValue(value),
+			Value: l.conv.convert(value),
 		}
 		keyValues = append(keyValues, kv)
+		i += 2
+	}
+	return keyValues
+}
+
+// appendMapAsKeyValues appends the entries of m as attributes to keyValues,
+// sorted by key.
+func (l *Logger) appendMapAsKeyValues(keyValues []log.KeyValue, m map[string]any) []log.KeyValue {
+	for _, key := range slices.Sorted(maps.Keys(m)) {
+		keyValues = append(keyValues, log.KeyValue{Key: key, Value: l.conv.convert(m[key])})
 	}
 	return keyValues
 }
 
 // addArgsAsAttributes processes alternating key-value arguments and adds them to the record.
-func addArgsAsAttributes(record *log.Record, args []any) {
-	keyValues := convertArgsToKeyValues(args)
-	record.AddAttributes(keyValues...)
+func (l *Logger) addArgsAsAttributes(record *log.Record, args []any) {
+	keyValues := l.convertArgsToKeyValues(args)
+	l.addCallAttributes(record, keyValues)
+}
+
+// addCallAttributes adds the attributes passed when logging to the record.
+func (l *Logger) addCallAttributes(record *log.Record, attrs []log.KeyValue) {
+	if l.namespace == "" {
+		record.AddAttributes(attrs...)
+		return
+	}
+	for _, kv := range attrs {
+		kv.Key = l.namespace + kv.Key
+		record.AddAttributes(kv)
+	}
 }
 
 // logAttr is the internal logging method that handles logging with log.KeyValue attributes.
 func (l *Logger) logAttr(ctx context.Context, level log.Severity, msg string, attrs []log.KeyValue) {
+	l.emit(ctx, l.attrRecord(ctx, level, msg, attrs))
+}
+
+// attrRecord returns the log record logged by logAttr.
+func (l *Logger) attrRecord(ctx context.Context, level log.Severity, msg string, attrs []log.KeyValue) log.Record {
 	var record log.Record
-	record.SetBody(log.StringValue(msg))
-	record.SetTimestamp(time.Now())
+	record.SetBody(l.body(ctx, msg))
+	record.SetTimestamp(l.now())
 	record.SetSeverity(level)
 
-	l.addKeyValueAttributes(&record, attrs)
-	l.Emit(ctx, record)
+	l.addKeyValueAttributes(ctx, &record, attrs)
+	return record
 }
 
 // addKeyValueAttributes adds log.KeyValue attributes to the record.
-func (l *Logger) addKeyValueAttributes(record *log.Record, attrs []log.KeyValue) {
+func (l *Logger) addKeyValueAttributes(ctx context.Context, record *log.Record, attrs []log.KeyValue) {
 	// Add pre-configured attributes first
-	record.AddAttributes(l.attrs...)
+	l.addLoggerAttributes(ctx, record)
 	// Then add call-specific attributes
-	record.AddAttributes(attrs...)
+	l.addCallAttributes(record, attrs)
+}
+
+// addLoggerAttributes adds the attributes added with With and WithAttr
+// and, if Options.UseGlobalHooks is set, the global attributes to the record.
+func (l *Logger) addLoggerAttributes(ctx context.Context, record *log.Record) {
+	l.addWithAttributes(record)
+	if l.cfg.globalHooks {
+		addGlobalAttributes(ctx, record)
+	}
+}
+
+// addWithAttributes adds the attributes added with With and WithAttr to the record.
+// If Options.CollapseWithAttrs is set, they are added as a single JSON-encoded attribute,
+// with Options.KeyAliases, Options.ValidateAttr, and Options.OmitEmpty applied to them.
+func (l *Logger) addWithAttributes(record *log.Record) {
+	if l.cfg.collapseKey == "" || l.attrs.Len() == 0 {
+		l.attrs.addTo(record)
+		return
+	}
+	kvs := l.attrs.All()
+	filtered := kvs[:0]
+	for _, kv := range kvs {
+		if kv, ok := l.cfg.filterAttr(kv); ok {
+			filtered = append(filtered, kv)
+		}
+	}
+	if len(filtered) == 0 {
+		return
+	}
+	collapsed, err := keyValuesToJSON(filtered)
+	if err != nil {
+		// Values such as NaN cannot be encoded, keep the attributes as they are.
+		l.attrs.addTo(record)
+		return
+	}
+	record.AddAttributes(log.String(l.cfg.collapseKey, collapsed))
 }
 
 // logEvent is the internal event logging method that handles the common event logging logic.
 func (l *Logger) logEvent(ctx context.Context, level log.Severity, name string, args []any) {
 	var record log.Record
-	record.SetEventName(name)
-	record.SetTimestamp(time.Now())
+	record.SetEventName(l.namespace + name)
+	record.SetTimestamp(l.now())
 	record.SetSeverity(level)
 
-	l.addAttributes(&record, args)
-	l.Emit(ctx, record)
+	l.addAttributes(ctx, &record, args)
+	l.emit(ctx, record)
 }
 
 // logEventAttr is the internal event logging method that handles event logging with log.KeyValue attributes.
 func (l *Logger) logEventAttr(ctx context.Context, level log.Severity, name string, attrs []log.KeyValue) {
 	var record log.Record
-	record.SetEventName(name)
-	record.SetTimestamp(time.Now())
+	record.SetEventName(l.namespace + name)
+	record.SetTimestamp(l.now())
+	record.SetSeverity(level)
+
+	l.addKeyValueAttributes(ctx, &record, attrs)
+	l.emit(ctx, record)
+}
+
+// logEventMsg is the internal event logging method for events that also have a message body.
+func (l *Logger) logEventMsg(ctx context.Context, level log.Severity, name, msg string, attrs []log.KeyValue) {
+	var record log.Record
+	record.SetEventName(l.namespace + name)
+	record.SetBody(l.body(ctx, msg))
+	record.SetTimestamp(l.now())
 	record.SetSeverity(level)
 
-	l.addKeyValueAttributes(&record, attrs)
-	l.Emit(ctx, record)
+	l.addKeyValueAttributes(ctx, &record, attrs)
+	l.emit(ctx, record)
+}
+
+// emit adds the emit-time attributes to the record and emits it
+// unless it is dropped by Options.MinSeverity or Options.Sampler.
+func (l *Logger) emit(ctx context.Context, record log.Record) {
+	l.emitFiltered(ctx, record, true)
+}
+
+// emitChecked emits a log record for which WillEmit already returned true.
+// The sampler is not run again, so that each record is sampled once.
+func (l *Logger) emitChecked(ctx context.Context, record log.Record) {
+	l.emitFiltered(ctx, record, false)
+}
+
+// emitFiltered emits the log record unless it is dropped, see dropReason.
+func (l *Logger) emitFiltered(ctx context.Context, record log.Record, sample bool) {
+	// The filters are loaded once so that a concurrent Reconfigure
+	// is observed either entirely or not at all.
+	f := l.cfg.filters.Load()
+	if reason := l.dropReason(ctx, f, record.Severity(), record.EventName(), sample); reason != "" {
+		if l.cfg.onDrop != nil {
+			l.cfg.onDrop(ctx, record, reason)
+		}
+		return
+	}
+	if l.cfg.levelText != nil {
+		record.SetSeverityText(l.cfg.levelText(record.Severity()))
+	}
+	if l.cfg.bucketFunc != nil {
+		record.AddAttributes(log.String(severityBucketKey, l.cfg.bucketFunc(record.Severity())))
+	}
+	record.AddAttributes(l.cfg.levelAttrs[record.Severity()]...)
+	for _, a := range l.severityAttrs {
+		if record.Severity() >= a.min {
+			record.AddAttributes(a.attrs...)
+		}
+	}
+	if l.cfg.nameKey != "" {
+		record.AddAttributes(log.String(l.cfg.nameKey, l.name))
+	}
+	record.AddAttributes(l.cfg.processAttrs...)
+	l.addContextAttributes(ctx, &record)
+	for _, fn := range l.ctxFuncs {
+		record.AddAttributes(fn(ctx)...)
+	}
+	l.addBaggageAttributes(ctx, &record)
+	if l.cfg.traceContext {
+		if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+			record.AddAttributes(spanContextAttrs(sc)...)
+		}
+	}
+	record = l.transformRecord(f, record)
+	if l.emitFn != nil {
+		l.emitFn(ctx, record)
+	} else {
+		l.Emit(ctx, record)
+	}
+	l.warnDeprecatedEvent(ctx, record.EventName())
+}
+
+// emitHooks returns the EmitFunc emitting the log records with logger and then
+// passing them to Options.ErrorCounter, Options.Tap, and Options.TrackLastEmitted,
+// or nil if none of them is set. It is the end of the middleware chain, so that
+// the hooks see the records actually emitted, including the summaries of the
+// middleware, and not the ones it dropped.
+func (c *config) emitHooks(logger log.Logger, name string) EmitFunc {
+	if c.errCounter == nil && c.tap == nil && c.lastEmitted == nil {
+		return nil
+	}
+	scopeAttr := metric.WithAttributes(attribute.String("otel.scope.name", name))
+	return func(ctx context.Context, r log.Record) {
+		logger.Emit(ctx, r)
+
+		if c.errCounter != nil && r.Severity() >= log.SeverityError1 {
+			c.errCounter.Add(ctx, 1, scopeAttr)
+		}
+		if c.tap != nil {
+			c.tap(ctx, r)
+		}
+		if c.lastEmitted != nil {
+			last := r.Clone()
+			c.lastEmitted.Store(&last)
+		}
+	}
+}
+
+// allowed reports whether a log record passes Options.MinSeverity,
+// the minimum severity set with WithMinSeverity, and Options.Sampler.
+func (l *Logger) allowed(ctx context.Context, level log.Severity, eventName string) bool {
+	return l.dropReason(ctx, l.cfg.filters.Load(), level, eventName, true) == ""
+}
+
+// dropReason returns the reason, as passed to Options.OnDrop, why a log record
+// does not pass the filters of allowed, or an empty string if it passes them.
+// The sampler is only run if sample is true.
+func (l *Logger) dropReason(ctx context.Context, f *filters, level log.Severity, eventName string, sample bool) string {
+	if level < f.minSeverity || level < l.minSeverity {
+		return "min_severity"
+	}
+	if !sample {
+		return ""
+	}
+	sampler := f.sampler
+	if l.ownSampler {
+		sampler = l.sampler
+	}
+	if sampler != nil && !sampler(ctx, level, eventName) {
+		return "sampled"
+	}
+	return ""
+}
+
+// addContextAttributes adds the values of Options.ContextKeys from ctx to the record.
+func (l *Logger) addContextAttributes(ctx context.Context, record *log.Record) {
+	for i, key := range l.cfg.contextKeys {
+		if v := ctx.Value(key); v != nil {
+			record.AddAttributes(log.KeyValue{Key: l.cfg.contextNames[i], Value: l.conv.convert(v)})
+		}
+	}
+}
+
+// addBaggageAttributes adds the selected baggage members from ctx to the record.
+func (l *Logger) addBaggageAttributes(ctx context.Context, record *log.Record) {
+	if len(l.baggageKeys) == 0 {
+		return
+	}
+	bag := baggage.FromContext(ctx)
+	for _, key := range l.baggageKeys {
+		member := bag.Member(key)
+		if member.Key() == "" {
+			continue
+		}
+		record.AddAttributes(log.String("baggage."+key, member.Value()))
+	}
 }
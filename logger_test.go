@@ -6,13 +6,19 @@ package olog
 import (
 	"context"
 	"errors"
+	"os"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/log"
 	"go.opentelemetry.io/otel/log/logtest"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/embedded"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestLogger_BasicOperations(t *testing.T) {
@@ -1381,3 +1387,1523 @@ func TestLogger_EmbeddedLogger(t *testing.T) {
 	} // Test that we can assign Logger to log.Logger interface
 	var _ log.Logger = logger
 }
+
+func TestLogger_WithBaggageKeys(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"})
+
+	userMember, err := baggage.NewMember("user.id", "42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	secretMember, err := baggage.NewMember("secret", "s3cr3t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bag, err := baggage.New(userMember, secretMember)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := baggage.ContextWithBaggage(t.Context(), bag)
+
+	logger.WithBaggageKeys("user.id", "missing").Info(ctx, "with baggage", "key", "value")
+
+	want := logtest.Recording{
+		logtest.Scope{
+			Name: "test-logger",
+		}: {
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityInfo,
+				Body:     log.StringValue("with baggage"),
+				Attributes: []log.KeyValue{
+					log.String("key", "value"),
+					log.String("baggage.user.id", "42"),
+				},
+			},
+		},
+	}
+
+	got := recorder.Result()
+	logtest.AssertEqual(t, want, got, logtest.Transform(func(r logtest.Record) logtest.Record {
+		r.Timestamp = time.Time{}
+		r.ObservedTimestamp = time.Time{}
+		return r
+	}))
+}
+
+func TestLogger_Assert(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"})
+
+	ctx := t.Context()
+
+	logger.Assert(ctx, 1+1 == 2, "math works")
+	logger.Assert(ctx, len("abc") == 2, "unexpected length", "len", 3)
+
+	want := logtest.Recording{
+		logtest.Scope{
+			Name: "test-logger",
+		}: {
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityError,
+				Body:     log.StringValue("unexpected length"),
+				Attributes: []log.KeyValue{
+					log.Int64("len", 3),
+					log.String("assertion", "failed"),
+				},
+			},
+		},
+	}
+
+	got := recorder.Result()
+	logtest.AssertEqual(t, want, got, logtest.Transform(func(r logtest.Record) logtest.Record {
+		r.Timestamp = time.Time{}
+		r.ObservedTimestamp = time.Time{}
+		return r
+	}))
+}
+
+func TestLogger_AssertNamespace(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"}).WithNamespace("db")
+
+	ctx := t.Context()
+	logger.Assert(ctx, false, "unexpected length", "len", 3)
+	_ = logger.WrapError(ctx, errors.New("connection refused"), "ping database")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+	// The marker attributes are not prefixed with the namespace.
+	want := []log.KeyValue{log.Int64("db.len", 3), log.String("assertion", "failed")}
+	if !equalKeyValues(records[0].Attributes, want) {
+		t.Errorf("got attributes %v, want %v", records[0].Attributes, want)
+	}
+	want = []log.KeyValue{log.String("error", "connection refused")}
+	if !equalKeyValues(records[1].Attributes, want) {
+		t.Errorf("got attributes %v, want %v", records[1].Attributes, want)
+	}
+}
+
+func TestLogger_AssertTrueDoesNotAllocate(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"})
+
+	ctx := t.Context()
+
+	allocs := testing.AllocsPerRun(100, func() {
+		logger.Assert(ctx, true, "never logged", "key", "value")
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations, got %v", allocs)
+	}
+
+	if got := len(recorder.Result()[logtest.Scope{Name: "test-logger"}]); got != 0 {
+		t.Errorf("expected no records, got %d", got)
+	}
+}
+
+func TestLogger_AssertPanics(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger", AssertPanics: true})
+
+	ctx := t.Context()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic on failed assertion")
+		}
+		if got := len(recorder.Result()[logtest.Scope{Name: "test-logger"}]); got != 1 {
+			t.Errorf("expected 1 record before panic, got %d", got)
+		}
+	}()
+	logger.Assert(ctx, false, "broken invariant")
+}
+
+func TestNew_IncludeHostAndPID(t *testing.T) {
+	calls := 0
+	orig := osHostname
+	osHostname = func() (string, error) {
+		calls++
+		return "test-host", nil
+	}
+	t.Cleanup(func() { osHostname = orig })
+
+	recorder := logtest.NewRecorder()
+	logger := New(Options{
+		Provider:    recorder,
+		Name:        "test-logger",
+		IncludeHost: true,
+		IncludePID:  true,
+	})
+
+	ctx := t.Context()
+	logger.Info(ctx, "first")
+	logger.With("key", "value").Info(ctx, "second")
+
+	want := logtest.Recording{
+		logtest.Scope{
+			Name: "test-logger",
+		}: {
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityInfo,
+				Body:     log.StringValue("first"),
+				Attributes: []log.KeyValue{
+					log.String("host.name", "test-host"),
+					log.Int("process.pid", os.Getpid()),
+				},
+			},
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityInfo,
+				Body:     log.StringValue("second"),
+				Attributes: []log.KeyValue{
+					log.String("key", "value"),
+					log.String("host.name", "test-host"),
+					log.Int("process.pid", os.Getpid()),
+				},
+			},
+		},
+	}
+
+	got := recorder.Result()
+	logtest.AssertEqual(t, want, got, logtest.Transform(func(r logtest.Record) logtest.Record {
+		r.Timestamp = time.Time{}
+		r.ObservedTimestamp = time.Time{}
+		return r
+	}))
+
+	if calls != 1 {
+		t.Errorf("expected hostname to be resolved once, got %d calls", calls)
+	}
+}
+
+func TestNew_IncludeHostFallback(t *testing.T) {
+	orig := osHostname
+	osHostname = func() (string, error) {
+		return "", errors.New("no hostname")
+	}
+	t.Cleanup(func() { osHostname = orig })
+
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger", IncludeHost: true})
+	logger.Info(t.Context(), "message")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+	want := []log.KeyValue{log.String("host.name", "unknown")}
+	if !equalKeyValues(records[0].Attributes, want) {
+		t.Errorf("got attributes %v, want %v", records[0].Attributes, want)
+	}
+}
+
+func equalKeyValues(a, b []log.KeyValue) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestLogger_CollapseWithAttrs(t *testing.T) {
+	ctx := t.Context()
+
+	emit := func(options Options) []log.KeyValue {
+		recorder := logtest.NewRecorder()
+		options.Provider = recorder
+		options.Name = "test-logger"
+		logger := New(options).
+			With("service", "api", "port", 8080).
+			WithAttr(log.Bool("debug", true))
+		logger.InfoAttr(ctx, "message", log.String("request_id", "req-1"))
+
+		records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+		if len(records) != 1 {
+			t.Fatalf("expected 1 record, got %d", len(records))
+		}
+		return records[0].Attributes
+	}
+
+	normal := emit(Options{})
+	wantNormal := []log.KeyValue{
+		log.String("service", "api"),
+		log.Int64("port", 8080),
+		log.Bool("debug", true),
+		log.String("request_id", "req-1"),
+	}
+	if !equalKeyValues(normal, wantNormal) {
+		t.Errorf("normal: got %v, want %v", normal, wantNormal)
+	}
+
+	collapsed := emit(Options{CollapseWithAttrs: "context"})
+	wantCollapsed := []log.KeyValue{
+		log.String("context", `{"debug":true,"port":8080,"service":"api"}`),
+		log.String("request_id", "req-1"),
+	}
+	if !equalKeyValues(collapsed, wantCollapsed) {
+		t.Errorf("collapsed: got %v, want %v", collapsed, wantCollapsed)
+	}
+}
+
+func TestLogger_CollapseWithAttrsFiltered(t *testing.T) {
+	var errs []error
+	recorder := logtest.NewRecorder()
+	logger := New(Options{
+		Provider:          recorder,
+		Name:              "test-logger",
+		CollapseWithAttrs: "context",
+		OmitEmpty:         true,
+		KeyAliases:        map[string]string{"port": "net.port"},
+		ValidateAttr: func(kv log.KeyValue) error {
+			if kv.Key == "debug" {
+				return errors.New("forbidden")
+			}
+			return nil
+		},
+		OnError: func(err error) { errs = append(errs, err) },
+	})
+	logger.With("service", "api", "empty", "", "port", 8080, "debug", true).Info(t.Context(), "message")
+	logger.With("empty", "").Info(t.Context(), "all omitted")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	want := []log.KeyValue{log.String("context", `{"net.port":8080,"service":"api"}`)}
+	if !equalKeyValues(records[0].Attributes, want) {
+		t.Errorf("got %v, want %v", records[0].Attributes, want)
+	}
+	if len(records[1].Attributes) != 0 {
+		t.Errorf("got %v, want no attributes", records[1].Attributes)
+	}
+	if len(errs) != 1 {
+		t.Errorf("got errors %v, want 1", errs)
+	}
+}
+
+func TestLogger_MinSeverityAndSampler(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{
+		Provider:    recorder,
+		Name:        "test-logger",
+		MinSeverity: log.SeverityInfo,
+		Sampler: func(_ context.Context, _ log.Severity, eventName string) bool {
+			return eventName != "noisy.event"
+		},
+	})
+
+	ctx := t.Context()
+	logger.Debug(ctx, "below threshold")
+	logger.Info(ctx, "at threshold")
+	logger.InfoEvent(ctx, "noisy.event")
+	logger.WarnEvent(ctx, "kept.event")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	if got := records[0].Body.AsString(); got != "at threshold" {
+		t.Errorf("got body %q, want %q", got, "at threshold")
+	}
+	if got := records[1].EventName; got != "kept.event" {
+		t.Errorf("got event name %q, want %q", got, "kept.event")
+	}
+}
+
+func TestLogger_WillEmit(t *testing.T) {
+	ctx := t.Context()
+
+	disabled := New(Options{
+		Provider: logtest.NewRecorder(logtest.WithEnabledFunc(func(context.Context, log.EnabledParameters) bool {
+			return false
+		})),
+		Name: "disabled",
+	})
+	if disabled.WillEmit(ctx, log.SeverityError) {
+		t.Error("expected WillEmit to be false for a disabled backend")
+	}
+
+	threshold := New(Options{
+		Provider:    logtest.NewRecorder(),
+		Name:        "threshold",
+		MinSeverity: log.SeverityWarn,
+	})
+	if threshold.WillEmit(ctx, log.SeverityInfo) {
+		t.Error("expected WillEmit to be false below MinSeverity")
+	}
+	if !threshold.WillEmit(ctx, log.SeverityWarn) {
+		t.Error("expected WillEmit to be true at MinSeverity")
+	}
+
+	dropAll := New(Options{
+		Provider: logtest.NewRecorder(),
+		Name:     "sampled",
+		Sampler:  func(context.Context, log.Severity, string) bool { return false },
+	})
+	if dropAll.WillEmit(ctx, log.SeverityError) {
+		t.Error("expected WillEmit to be false when the sampler drops")
+	}
+
+	passThrough := New(Options{Provider: logtest.NewRecorder(), Name: "pass-through"})
+	if !passThrough.WillEmit(ctx, log.SeverityTrace) {
+		t.Error("expected WillEmit to be true with no filters")
+	}
+}
+
+type testContextKey string
+
+func TestLogger_ContextKeys(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{
+		Provider:        recorder,
+		Name:            "test-logger",
+		ContextKeys:     []any{testContextKey("request"), testContextKey("user")},
+		ContextKeyNames: []string{"request.id", "user.id"},
+	})
+
+	ctx := context.WithValue(t.Context(), testContextKey("request"), "req-123")
+	logger.Info(ctx, "message", "key", "value")
+
+	want := logtest.Recording{
+		logtest.Scope{
+			Name: "test-logger",
+		}: {
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityInfo,
+				Body:     log.StringValue("message"),
+				Attributes: []log.KeyValue{
+					log.String("key", "value"),
+					log.String("request.id", "req-123"),
+				},
+			},
+		},
+	}
+
+	got := recorder.Result()
+	logtest.AssertEqual(t, want, got, logtest.Transform(func(r logtest.Record) logtest.Record {
+		r.Timestamp = time.Time{}
+		r.ObservedTimestamp = time.Time{}
+		return r
+	}))
+}
+
+func TestNew_ContextKeysLengthMismatch(t *testing.T) {
+	var gotErr error
+	New(Options{
+		Provider:        logtest.NewRecorder(),
+		ContextKeys:     []any{testContextKey("a"), testContextKey("b")},
+		ContextKeyNames: []string{"a"},
+		OnError:         func(err error) { gotErr = err },
+	})
+	if gotErr == nil {
+		t.Error("expected OnError to be called")
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expected panic in strict mode")
+		}
+	}()
+	New(Options{
+		Provider:    logtest.NewRecorder(),
+		ContextKeys: []any{testContextKey("a")},
+		Strict:      true,
+	})
+}
+
+func TestLogger_LogWithTimes(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"})
+
+	ctx := t.Context()
+	ts := time.Date(2020, time.January, 2, 3, 4, 5, 6, time.UTC)
+	observed := time.Date(2020, time.January, 2, 3, 4, 7, 8, time.UTC)
+	logger.LogWithTimes(ctx, log.SeverityWarn, "replayed", ts, observed, log.String("key", "value"))
+
+	want := logtest.Recording{
+		logtest.Scope{
+			Name: "test-logger",
+		}: {
+			logtest.Record{
+				Context:           ctx,
+				Timestamp:         ts,
+				ObservedTimestamp: observed,
+				Severity:          log.SeverityWarn,
+				Body:              log.StringValue("replayed"),
+				Attributes: []log.KeyValue{
+					log.String("key", "value"),
+				},
+			},
+		},
+	}
+
+	logtest.AssertEqual(t, want, recorder.Result())
+}
+
+func TestLogger_AttrFingerprint(t *testing.T) {
+	base := New(Options{Provider: logtest.NewRecorder(), Name: "test-logger"})
+
+	a := base.With("service", "api", "port", 8080).WithAttr(log.Bool("debug", true))
+	b := base.WithAttr(log.Bool("debug", true)).With("port", 8080, "service", "api")
+	c := base.With("service", "api", "port", 8081).WithAttr(log.Bool("debug", true))
+	d := base.With("service", "api", "port", "8080").WithAttr(log.Bool("debug", true))
+
+	if a.AttrFingerprint() != b.AttrFingerprint() {
+		t.Error("expected equal fingerprints for reordered attributes")
+	}
+	if a.AttrFingerprint() == c.AttrFingerprint() {
+		t.Error("expected different fingerprints for different values")
+	}
+	if a.AttrFingerprint() == d.AttrFingerprint() {
+		t.Error("expected different fingerprints for different value kinds")
+	}
+
+	var calls int
+	lazy := a.WithAttrsLazy(func() []log.KeyValue {
+		calls++
+		return []log.KeyValue{log.String("lazy", "v")}
+	}).WithLogValuer("valuer", LogValuerFunc(func() log.Value {
+		calls++
+		return log.StringValue("v")
+	}))
+	if lazy.AttrFingerprint() != a.AttrFingerprint() {
+		t.Error("expected lazy attributes not to change the fingerprint")
+	}
+	if calls != 0 {
+		t.Errorf("got %d calls of the lazy functions, want 0", calls)
+	}
+}
+
+func TestLogger_WithTraceIDAndSpanID(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"})
+
+	ctx := t.Context()
+	logger.WithTraceID("4bf92f3577b34da6a3ce929d0e0e4736").
+		WithSpanID("00f067aa0ba902b7").
+		Info(ctx, "correlated")
+
+	want := logtest.Recording{
+		logtest.Scope{
+			Name: "test-logger",
+		}: {
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityInfo,
+				Body:     log.StringValue("correlated"),
+				Attributes: []log.KeyValue{
+					log.String("trace_id", "4bf92f3577b34da6a3ce929d0e0e4736"),
+					log.String("span_id", "00f067aa0ba902b7"),
+				},
+			},
+		},
+	}
+
+	got := recorder.Result()
+	logtest.AssertEqual(t, want, got, logtest.Transform(func(r logtest.Record) logtest.Record {
+		r.Timestamp = time.Time{}
+		r.ObservedTimestamp = time.Time{}
+		return r
+	}))
+}
+
+func TestLogger_WithNamespace(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"})
+
+	ctx := t.Context()
+	dbLogger := logger.With("service", "api").WithNamespace("db").With("host", "localhost")
+	dbLogger.WithNamespace("pool").InfoEvent(ctx, "exhausted", "size", 10)
+	dbLogger.InfoAttr(ctx, "connected", log.Int("latency_ms", 5))
+
+	want := logtest.Recording{
+		logtest.Scope{
+			Name: "test-logger",
+		}: {
+			logtest.Record{
+				Context:   ctx,
+				Severity:  log.SeverityInfo,
+				EventName: "db.pool.exhausted",
+				Attributes: []log.KeyValue{
+					log.String("service", "api"),
+					log.String("db.host", "localhost"),
+					log.Int64("db.pool.size", 10),
+				},
+			},
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityInfo,
+				Body:     log.StringValue("connected"),
+				Attributes: []log.KeyValue{
+					log.String("service", "api"),
+					log.String("db.host", "localhost"),
+					log.Int("db.latency_ms", 5),
+				},
+			},
+		},
+	}
+
+	got := recorder.Result()
+	logtest.AssertEqual(t, want, got, logtest.Transform(func(r logtest.Record) logtest.Record {
+		r.Timestamp = time.Time{}
+		r.ObservedTimestamp = time.Time{}
+		return r
+	}))
+}
+
+func TestNew_TimeFormat(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger", TimeFormat: time.RFC3339})
+
+	ts := time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)
+	logger.With("started_at", ts).Info(t.Context(), "message", "finished_at", ts.Add(time.Hour))
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+	want := []log.KeyValue{
+		log.String("started_at", "2024-03-05T14:30:00Z"),
+		log.String("finished_at", "2024-03-05T15:30:00Z"),
+	}
+	if !equalKeyValues(records[0].Attributes, want) {
+		t.Errorf("got attributes %v, want %v", records[0].Attributes, want)
+	}
+}
+
+func TestLogger_Lazy(t *testing.T) {
+	ctx := t.Context()
+
+	disabled := New(Options{
+		Provider: logtest.NewRecorder(logtest.WithEnabledFunc(func(_ context.Context, p log.EnabledParameters) bool {
+			return p.Severity >= log.SeverityInfo
+		})),
+		Name: "disabled",
+	})
+	called := false
+	fn := func() (string, []log.KeyValue) {
+		called = true
+		return "expensive", nil
+	}
+	disabled.TraceLazy(ctx, fn)
+	disabled.DebugLazy(ctx, fn)
+	if called {
+		t.Error("expected fn not to be called when the level is disabled")
+	}
+
+	recorder := logtest.NewRecorder()
+	enabled := New(Options{Provider: recorder, Name: "enabled"})
+	enabled.TraceLazy(ctx, func() (string, []log.KeyValue) {
+		return "trace state", []log.KeyValue{log.Int("size", 1)}
+	})
+	enabled.DebugLazy(ctx, func() (string, []log.KeyValue) {
+		return "debug state", []log.KeyValue{log.Int("size", 2)}
+	})
+
+	want := logtest.Recording{
+		logtest.Scope{
+			Name: "enabled",
+		}: {
+			logtest.Record{
+				Context:    ctx,
+				Severity:   log.SeverityTrace,
+				Body:       log.StringValue("trace state"),
+				Attributes: []log.KeyValue{log.Int("size", 1)},
+			},
+			logtest.Record{
+				Context:    ctx,
+				Severity:   log.SeverityDebug,
+				Body:       log.StringValue("debug state"),
+				Attributes: []log.KeyValue{log.Int("size", 2)},
+			},
+		},
+	}
+
+	got := recorder.Result()
+	logtest.AssertEqual(t, want, got, logtest.Transform(func(r logtest.Record) logtest.Record {
+		r.Timestamp = time.Time{}
+		r.ObservedTimestamp = time.Time{}
+		return r
+	}))
+}
+
+func TestLogger_LazySamplesOnce(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	var calls int
+	logger := New(Options{
+		Provider: recorder,
+		Name:     "test-logger",
+		Sampler: func(context.Context, log.Severity, string) bool {
+			calls++
+			return true
+		},
+	})
+
+	logger.DebugLazy(t.Context(), func() (string, []log.KeyValue) {
+		return "state", nil
+	})
+
+	if calls != 1 {
+		t.Errorf("got %d sampler calls, want 1", calls)
+	}
+	if got := len(recorder.Result()[logtest.Scope{Name: "test-logger"}]); got != 1 {
+		t.Errorf("got %d records, want 1", got)
+	}
+}
+
+func TestLogger_WithContextFunc(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"})
+
+	tenant := func(ctx context.Context) []log.KeyValue {
+		if v, ok := ctx.Value(testContextKey("tenant")).(string); ok {
+			return []log.KeyValue{log.String("tenant", v)}
+		}
+		return nil
+	}
+	region := func(context.Context) []log.KeyValue {
+		return []log.KeyValue{log.String("region", "eu")}
+	}
+
+	ctx := context.WithValue(t.Context(), testContextKey("tenant"), "acme")
+	logger.With("service", "api").
+		WithContextFunc(tenant).
+		WithContextFunc(region).
+		Info(ctx, "message", "key", "value")
+
+	want := logtest.Recording{
+		logtest.Scope{
+			Name: "test-logger",
+		}: {
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityInfo,
+				Body:     log.StringValue("message"),
+				Attributes: []log.KeyValue{
+					log.String("service", "api"),
+					log.String("key", "value"),
+					log.String("tenant", "acme"),
+					log.String("region", "eu"),
+				},
+			},
+		},
+	}
+
+	got := recorder.Result()
+	logtest.AssertEqual(t, want, got, logtest.Transform(func(r logtest.Record) logtest.Record {
+		r.Timestamp = time.Time{}
+		r.ObservedTimestamp = time.Time{}
+		return r
+	}))
+}
+
+func TestLogger_EnabledAny(t *testing.T) {
+	logger := New(Options{
+		Provider: logtest.NewRecorder(logtest.WithEnabledFunc(func(_ context.Context, p log.EnabledParameters) bool {
+			return p.Severity == log.SeverityError
+		})),
+		Name: "test-logger",
+	})
+
+	ctx := t.Context()
+	if !logger.EnabledAny(ctx, log.SeverityDebug, log.SeverityError) {
+		t.Error("expected EnabledAny(Debug, Error) to be true")
+	}
+	if logger.EnabledAny(ctx, log.SeverityTrace, log.SeverityDebug) {
+		t.Error("expected EnabledAny(Trace, Debug) to be false")
+	}
+	if logger.EnabledAny(ctx) {
+		t.Error("expected EnabledAny() to be false")
+	}
+}
+
+func TestNew_Tap(t *testing.T) {
+	counts := map[log.Severity]int{}
+	recorder := logtest.NewRecorder()
+	logger := New(Options{
+		Provider:    recorder,
+		Name:        "test-logger",
+		MinSeverity: log.SeverityInfo,
+		Tap: func(_ context.Context, r log.Record) {
+			counts[r.Severity()]++
+		},
+	})
+
+	ctx := t.Context()
+	logger.Debug(ctx, "dropped")
+	logger.Info(ctx, "info 1")
+	logger.Info(ctx, "info 2")
+	logger.With("key", "value").Error(ctx, "error")
+
+	want := map[log.Severity]int{
+		log.SeverityInfo:  2,
+		log.SeverityError: 1,
+	}
+	if len(counts) != len(want) {
+		t.Fatalf("got counts %v, want %v", counts, want)
+	}
+	for level, n := range want {
+		if counts[level] != n {
+			t.Errorf("got %d %v records, want %d", counts[level], level, n)
+		}
+	}
+	if got := len(recorder.Result()[logtest.Scope{Name: "test-logger"}]); got != 3 {
+		t.Errorf("expected the tap not to affect emitted records, got %d records", got)
+	}
+}
+
+func TestNew_SeverityAttrs(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{
+		Provider: recorder,
+		Name:     "test-logger",
+		SeverityAttrs: map[log.Severity][]log.KeyValue{
+			log.SeverityError: {log.Bool("alert", true)},
+		},
+	})
+
+	ctx := t.Context()
+	logger.With("service", "api").Info(ctx, "info message")
+	logger.With("service", "api").Error(ctx, "error message", "key", "value")
+
+	want := logtest.Recording{
+		logtest.Scope{
+			Name: "test-logger",
+		}: {
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityInfo,
+				Body:     log.StringValue("info message"),
+				Attributes: []log.KeyValue{
+					log.String("service", "api"),
+				},
+			},
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityError,
+				Body:     log.StringValue("error message"),
+				Attributes: []log.KeyValue{
+					log.String("service", "api"),
+					log.String("key", "value"),
+					log.Bool("alert", true),
+				},
+			},
+		},
+	}
+
+	got := recorder.Result()
+	logtest.AssertEqual(t, want, got, logtest.Transform(func(r logtest.Record) logtest.Record {
+		r.Timestamp = time.Time{}
+		r.ObservedTimestamp = time.Time{}
+		return r
+	}))
+}
+
+func TestLogger_WithSpanContext(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"})
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	ctx := t.Context()
+	logger.WithSpanContext(sc).Info(ctx, "valid")
+	logger.WithSpanContext(trace.SpanContext{}).Info(ctx, "invalid")
+
+	want := logtest.Recording{
+		logtest.Scope{
+			Name: "test-logger",
+		}: {
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityInfo,
+				Body:     log.StringValue("valid"),
+				Attributes: []log.KeyValue{
+					log.String("trace_id", "4bf92f3577b34da6a3ce929d0e0e4736"),
+					log.String("span_id", "00f067aa0ba902b7"),
+					log.String("trace_flags", "01"),
+				},
+			},
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityInfo,
+				Body:     log.StringValue("invalid"),
+			},
+		},
+	}
+
+	got := recorder.Result()
+	logtest.AssertEqual(t, want, got, logtest.Transform(func(r logtest.Record) logtest.Record {
+		r.Timestamp = time.Time{}
+		r.ObservedTimestamp = time.Time{}
+		return r
+	}))
+}
+
+func TestLogger_Named(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "a", Version: "v1.2.3"}).With("key", "value")
+
+	ctx := t.Context()
+	named := logger.Named("b")
+	named.Info(ctx, "from b")
+	named.Named("c").Info(ctx, "from c")
+	logger.Info(ctx, "from a")
+
+	want := logtest.Recording{
+		logtest.Scope{Name: "a", Version: "v1.2.3"}: {
+			logtest.Record{
+				Context:    ctx,
+				Severity:   log.SeverityInfo,
+				Body:       log.StringValue("from a"),
+				Attributes: []log.KeyValue{log.String("key", "value")},
+			},
+		},
+		logtest.Scope{Name: "a.b", Version: "v1.2.3"}: {
+			logtest.Record{
+				Context:    ctx,
+				Severity:   log.SeverityInfo,
+				Body:       log.StringValue("from b"),
+				Attributes: []log.KeyValue{log.String("key", "value")},
+			},
+		},
+		logtest.Scope{Name: "a.b.c", Version: "v1.2.3"}: {
+			logtest.Record{
+				Context:    ctx,
+				Severity:   log.SeverityInfo,
+				Body:       log.StringValue("from c"),
+				Attributes: []log.KeyValue{log.String("key", "value")},
+			},
+		},
+	}
+
+	logtest.AssertEqual(t, want, recorder.Result(),
+		logtest.Transform(func(r logtest.Record) logtest.Record {
+			r.Timestamp = time.Time{}
+			r.ObservedTimestamp = time.Time{}
+			return r
+		}),
+	)
+}
+
+func TestLogger_WithTenant(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		tenantInName bool
+		wantScope    string
+	}{
+		{name: "attribute only", tenantInName: false, wantScope: "a"},
+		{name: "in name", tenantInName: true, wantScope: "a.tenant.acme"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := logtest.NewRecorder()
+			logger := New(Options{Provider: recorder, Name: "a", TenantInName: tt.tenantInName}).WithNamespace("app")
+
+			logger.WithTenant("acme").Info(t.Context(), "hello", "key", "value")
+
+			records := recorder.Result()[logtest.Scope{Name: tt.wantScope}]
+			if len(records) != 1 {
+				t.Fatalf("got %d records in scope %q, want 1", len(records), tt.wantScope)
+			}
+			want := []log.KeyValue{log.String("tenant.id", "acme"), log.String("app.key", "value")}
+			if !equalKeyValues(records[0].Attributes, want) {
+				t.Errorf("got attributes %v, want %v", records[0].Attributes, want)
+			}
+		})
+	}
+}
+
+func TestNew_Now(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	fixed := time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)
+	logger := New(Options{Provider: recorder, Name: "test-logger", Now: func() time.Time { return fixed }})
+
+	ctx := t.Context()
+	logger.Info(ctx, "info")
+	logger.InfoEvent(ctx, "event")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	for _, r := range records {
+		if !fixed.Equal(r.Timestamp) {
+			t.Errorf("got timestamp %v, want %v", r.Timestamp, fixed)
+		}
+	}
+}
+
+func TestLogger_WithClock(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"})
+	fixed := time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)
+
+	ctx := t.Context()
+	logger.WithClock(func() time.Time { return fixed }).With("key", "value").Info(ctx, "fixed")
+	logger.Info(ctx, "now")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	if !fixed.Equal(records[0].Timestamp) {
+		t.Errorf("got timestamp %v, want %v", records[0].Timestamp, fixed)
+	}
+	if fixed.Equal(records[1].Timestamp) {
+		t.Error("the parent logger should keep its clock")
+	}
+}
+
+func TestNew_UseMonotonic(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		useMonotonic  bool
+		wantMonotonic bool
+	}{
+		{name: "stripped", useMonotonic: false, wantMonotonic: false},
+		{name: "kept", useMonotonic: true, wantMonotonic: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := logtest.NewRecorder()
+			logger := New(Options{Provider: recorder, Name: "test-logger", UseMonotonic: tt.useMonotonic})
+
+			logger.Info(t.Context(), "msg")
+
+			records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+			if len(records) != 1 {
+				t.Fatalf("expected 1 record, got %d", len(records))
+			}
+			// Only times with a monotonic clock reading print it as "m=".
+			gotMonotonic := strings.Contains(records[0].Timestamp.String(), "m=")
+			if gotMonotonic != tt.wantMonotonic {
+				t.Errorf("got timestamp %v, want monotonic clock reading %t", records[0].Timestamp, tt.wantMonotonic)
+			}
+		})
+	}
+}
+
+func TestLogger_ConvertArgsToKeyValuesSinglePair(t *testing.T) {
+	type point struct{ X, Y int }
+
+	for _, tt := range []struct {
+		name    string
+		flatten bool
+		args    []any
+		want    []log.KeyValue
+	}{
+		{
+			name: "string key",
+			args: []any{"key", 42},
+			want: []log.KeyValue{log.Int64("key", 42)},
+		},
+		{
+			name: "nil value",
+			args: []any{"key", nil},
+			want: []log.KeyValue{{Key: "key"}},
+		},
+		{
+			name: "non-string key",
+			args: []any{1, "value"},
+			want: []log.KeyValue{},
+		},
+		{
+			name:    "struct key flattened",
+			flatten: true,
+			args:    []any{point{X: 1, Y: 2}, "odd"},
+			want:    []log.KeyValue{log.Int64("X", 1), log.Int64("Y", 2), log.String("odd", "")},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := New(Options{Provider: logtest.NewRecorder(), Name: "test-logger", FlattenStructArgs: tt.flatten})
+
+			got := logger.convertArgsToKeyValues(tt.args)
+			if !equalKeyValues(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			// The fast path must match the general conversion.
+			general := logger.appendArgsAsKeyValues(nil, tt.args)
+			if !equalKeyValues(got, general) {
+				t.Errorf("fast path %v differs from general conversion %v", got, general)
+			}
+		})
+	}
+}
+
+type blockingFlushRecorder struct {
+	*logtest.Recorder
+	release chan struct{}
+}
+
+func (r *blockingFlushRecorder) ForceFlush(context.Context) error {
+	<-r.release
+	return nil
+}
+
+func TestLogger_FlushTimeout(t *testing.T) {
+	provider := &blockingFlushRecorder{Recorder: logtest.NewRecorder(), release: make(chan struct{})}
+	t.Cleanup(func() { close(provider.release) })
+	logger := New(Options{Provider: provider, Name: "test-logger"})
+
+	err := logger.FlushTimeout(10 * time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestLogger_FlushTimeoutCompleted(t *testing.T) {
+	provider := &flushRecorder{Recorder: logtest.NewRecorder(), err: errors.New("flush failed")}
+	logger := New(Options{Provider: provider, Name: "test-logger"})
+
+	err := logger.FlushTimeout(time.Minute)
+	if !errors.Is(err, provider.err) {
+		t.Errorf("got error %v, want %v", err, provider.err)
+	}
+	if provider.flushes != 1 {
+		t.Errorf("expected 1 flush, got %d", provider.flushes)
+	}
+}
+
+func TestLogger_WithMinSeverity(t *testing.T) {
+	ctx := t.Context()
+	logger := New(Options{
+		Provider:    logtest.NewRecorder(),
+		Name:        "test-logger",
+		MinSeverity: log.SeverityDebug,
+	})
+
+	quiet := logger.WithMinSeverity(log.SeverityWarn)
+	verbose := quiet.WithMinSeverity(log.SeverityDebug)
+	belowGlobal := logger.WithMinSeverity(log.SeverityTrace)
+
+	for _, tt := range []struct {
+		name   string
+		logger *Logger
+		level  log.Severity
+		want   bool
+	}{
+		{name: "parent debug", logger: logger, level: log.SeverityDebug, want: true},
+		{name: "child raises floor info", logger: quiet, level: log.SeverityInfo, want: false},
+		{name: "child raises floor warn", logger: quiet, level: log.SeverityWarn, want: true},
+		{name: "grandchild lowers floor debug", logger: verbose, level: log.SeverityDebug, want: true},
+		{name: "grandchild lowers floor trace", logger: verbose, level: log.SeverityTrace, want: false},
+		{name: "global floor is kept", logger: belowGlobal, level: log.SeverityTrace, want: false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.logger.WillEmit(ctx, tt.level); got != tt.want {
+				t.Errorf("WillEmit(%v) = %t, want %t", tt.level, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogger_WithMinSeverityEmit(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"})
+
+	ctx := t.Context()
+	quiet := logger.WithMinSeverity(log.SeverityWarn)
+	quiet.Info(ctx, "dropped")
+	quiet.With("key", "value").Warn(ctx, "kept")
+	logger.Info(ctx, "parent")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	if got := records[0].Body.AsString(); got != "kept" {
+		t.Errorf("got body %q, want %q", got, "kept")
+	}
+	if got := records[1].Body.AsString(); got != "parent" {
+		t.Errorf("got body %q, want %q", got, "parent")
+	}
+}
+
+type fakeCounter struct {
+	embedded.Int64Counter
+	adds []metric.AddConfig
+	sum  int64
+}
+
+func (c *fakeCounter) Add(_ context.Context, incr int64, opts ...metric.AddOption) {
+	c.sum += incr
+	c.adds = append(c.adds, metric.NewAddConfig(opts))
+}
+
+func TestNew_ErrorCounter(t *testing.T) {
+	counter := &fakeCounter{}
+	logger := New(Options{Provider: logtest.NewRecorder(), Name: "test-logger", ErrorCounter: counter})
+
+	ctx := t.Context()
+	logger.Info(ctx, "info")
+	logger.Warn(ctx, "warn")
+	logger.Error(ctx, "error")
+	logger.ErrorEvent(ctx, "failure")
+	logger.Log(ctx, log.SeverityFatal, "fatal")
+
+	if counter.sum != 3 {
+		t.Errorf("got counter %d, want 3", counter.sum)
+	}
+	for _, cfg := range counter.adds {
+		attrs := cfg.Attributes()
+		got, _ := attrs.Value("otel.scope.name")
+		if got.AsString() != "test-logger" {
+			t.Errorf("got otel.scope.name %q, want %q", got.AsString(), "test-logger")
+		}
+	}
+}
+
+func TestNew_ErrorCounterNil(t *testing.T) {
+	logger := New(Options{Provider: logtest.NewRecorder(), Name: "test-logger"})
+
+	// No counter is set, logging errors must not panic.
+	logger.Error(t.Context(), "error")
+}
+
+func TestNew_BodyFunc(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{
+		Provider: recorder,
+		Name:     "test-logger",
+		BodyFunc: func(_ context.Context, body string) string { return strings.ToUpper(body) },
+	})
+
+	ctx := t.Context()
+	logger.Info(ctx, "args message", "key", "value")
+	logger.WarnAttr(ctx, "attr message")
+	logger.InfoEvent(ctx, "user.login")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(records))
+	}
+	if got := records[0].Body.AsString(); got != "ARGS MESSAGE" {
+		t.Errorf("got body %q, want %q", got, "ARGS MESSAGE")
+	}
+	if got := records[1].Body.AsString(); got != "ATTR MESSAGE" {
+		t.Errorf("got body %q, want %q", got, "ATTR MESSAGE")
+	}
+	if got := records[2].EventName; got != "user.login" {
+		t.Errorf("got event name %q, want %q", got, "user.login")
+	}
+	if !records[2].Body.Empty() {
+		t.Errorf("expected empty event body, got %v", records[2].Body)
+	}
+}
+
+func TestNew_WithTraceContext(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger", WithTraceContext: true})
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	})
+	spanCtx := trace.ContextWithSpanContext(t.Context(), sc)
+	ctx := t.Context()
+
+	logger.Info(spanCtx, "in span", "key", "value")
+	logger.Info(ctx, "no span")
+
+	want := logtest.Recording{
+		logtest.Scope{
+			Name: "test-logger",
+		}: {
+			logtest.Record{
+				Context:  spanCtx,
+				Severity: log.SeverityInfo,
+				Body:     log.StringValue("in span"),
+				Attributes: []log.KeyValue{
+					log.String("key", "value"),
+					log.String("trace_id", "4bf92f3577b34da6a3ce929d0e0e4736"),
+					log.String("span_id", "00f067aa0ba902b7"),
+					log.String("trace_flags", "01"),
+				},
+			},
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityInfo,
+				Body:     log.StringValue("no span"),
+			},
+		},
+	}
+
+	got := recorder.Result()
+	logtest.AssertEqual(t, want, got, logtest.Transform(func(r logtest.Record) logtest.Record {
+		r.Timestamp = time.Time{}
+		r.ObservedTimestamp = time.Time{}
+		return r
+	}))
+}
+
+func TestNew_ExpandMapArgs(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger", ExpandMapArgs: true})
+
+	ctx := t.Context()
+	fields := map[string]any{"zone": "eu-1", "attempt": 3, "cached": true}
+	logger.Info(ctx, "fields", fields, "extra", "x")
+	logger.Info(ctx, "nested", "fields", map[string]any{"a": 1})
+
+	want := logtest.Recording{
+		logtest.Scope{
+			Name: "test-logger",
+		}: {
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityInfo,
+				Body:     log.StringValue("fields"),
+				Attributes: []log.KeyValue{
+					log.Int64("attempt", 3),
+					log.Bool("cached", true),
+					log.String("zone", "eu-1"),
+					log.String("extra", "x"),
+				},
+			},
+			logtest.Record{
+				Context:    ctx,
+				Severity:   log.SeverityInfo,
+				Body:       log.StringValue("nested"),
+				Attributes: []log.KeyValue{log.Map("fields", log.Int64("a", 1))},
+			},
+		},
+	}
+
+	got := recorder.Result()
+	logtest.AssertEqual(t, want, got, logtest.Transform(func(r logtest.Record) logtest.Record {
+		r.Timestamp = time.Time{}
+		r.ObservedTimestamp = time.Time{}
+		return r
+	}))
+}
+
+func TestLogger_WithSampler(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{
+		Provider: recorder,
+		Name:     "test-logger",
+		Sampler: func(_ context.Context, _ log.Severity, eventName string) bool {
+			return eventName != "noisy"
+		},
+	})
+
+	ctx := t.Context()
+	inherited := logger.With("child", 1)
+	inherited.InfoEvent(ctx, "noisy")
+	inherited.InfoEvent(ctx, "kept")
+
+	dropAll := inherited.WithSampler(func(context.Context, log.Severity, string) bool { return false })
+	dropAll.With("grandchild", 2).Error(ctx, "dropped")
+
+	keepAll := dropAll.WithSampler(nil)
+	keepAll.InfoEvent(ctx, "noisy")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	if got := records[0].EventName; got != "kept" {
+		t.Errorf("got event name %q, want %q", got, "kept")
+	}
+	if got := records[1].EventName; got != "noisy" {
+		t.Errorf("got event name %q, want %q", got, "noisy")
+	}
+	want := []log.KeyValue{log.Int64("child", 1)}
+	if !equalKeyValues(records[1].Attributes, want) {
+		t.Errorf("got attributes %v, want %v", records[1].Attributes, want)
+	}
+}
+
+func TestLogger_AttrAt(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	now := time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)
+	logger := New(Options{Provider: recorder, Name: "test-logger", Now: func() time.Time { return now }})
+
+	ctx := t.Context()
+	ts := time.Date(2020, time.January, 2, 3, 4, 5, 6, time.UTC)
+	logger.TraceAttrAt(ctx, ts, "trace", log.Int("n", 1))
+	logger.DebugAttrAt(ctx, ts, "debug")
+	logger.InfoAttrAt(ctx, ts, "info")
+	logger.WarnAttrAt(ctx, ts, "warn")
+	logger.ErrorAttrAt(ctx, ts, "error")
+
+	record := func(level log.Severity, msg string, attrs ...log.KeyValue) logtest.Record {
+		return logtest.Record{
+			Context:           ctx,
+			Timestamp:         ts,
+			ObservedTimestamp: now,
+			Severity:          level,
+			Body:              log.StringValue(msg),
+			Attributes:        attrs,
+		}
+	}
+	want := logtest.Recording{
+		logtest.Scope{
+			Name: "test-logger",
+		}: {
+			record(log.SeverityTrace, "trace", log.Int("n", 1)),
+			record(log.SeverityDebug, "debug"),
+			record(log.SeverityInfo, "info"),
+			record(log.SeverityWarn, "warn"),
+			record(log.SeverityError, "error"),
+		},
+	}
+
+	logtest.AssertEqual(t, want, recorder.Result())
+}
+
+func TestLogger_OnDrop(t *testing.T) {
+	type drop struct {
+		body   string
+		reason string
+	}
+	var drops []drop
+	recorder := logtest.NewRecorder()
+	logger := New(Options{
+		Provider:    recorder,
+		Name:        "test-logger",
+		MinSeverity: log.SeverityInfo,
+		Sampler: func(_ context.Context, _ log.Severity, eventName string) bool {
+			return eventName != "noisy"
+		},
+		OnDrop: func(_ context.Context, r log.Record, reason string) {
+			drops = append(drops, drop{body: r.Body().AsString(), reason: reason})
+		},
+	})
+
+	ctx := t.Context()
+	logger.Debug(ctx, "debug")
+	logger.InfoEvent(ctx, "noisy", "msg", "sampled")
+	logger.WithMinSeverity(log.SeverityError).Warn(ctx, "warn")
+	logger.Info(ctx, "info")
+
+	want := []drop{
+		{body: "debug", reason: "min_severity"},
+		{body: "", reason: "sampled"},
+		{body: "warn", reason: "min_severity"},
+	}
+	if !slices.Equal(drops, want) {
+		t.Errorf("got drops %v, want %v", drops, want)
+	}
+	if got := len(recorder.Result()[logtest.Scope{Name: "test-logger"}]); got != 1 {
+		t.Errorf("got %d records, want 1", got)
+	}
+}
+
+func TestLogger_WithAttrFromSeverity(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"})
+
+	ctx := t.Context()
+	reqLogger := logger.With("request.id", "r1").
+		WithAttrFromSeverity(log.SeverityWarn, log.String("request.headers", "h")).
+		WithAttrFromSeverity(log.SeverityError, log.String("request.body", "b"))
+	reqLogger.Info(ctx, "info", "n", 1)
+	reqLogger.Warn(ctx, "warn")
+	reqLogger.ErrorEvent(ctx, "failed")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(records))
+	}
+	for i, want := range [][]log.KeyValue{
+		{log.String("request.id", "r1"), log.Int64("n", 1)},
+		{log.String("request.id", "r1"), log.String("request.headers", "h")},
+		{log.String("request.id", "r1"), log.String("request.headers", "h"), log.String("request.body", "b")},
+	} {
+		if !equalKeyValues(records[i].Attributes, want) {
+			t.Errorf("record %d: got attributes %v, want %v", i, records[i].Attributes, want)
+		}
+	}
+}
+
+func TestNew_EmitStartupRecord(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{
+		Provider:          recorder,
+		Name:              "test-logger",
+		Version:           "v1.2.3",
+		MinSeverity:       log.SeverityDebug,
+		EmitStartupRecord: true,
+	})
+	logger.Info(t.Context(), "after")
+	New(Options{Provider: recorder, Name: "test-logger", Version: "v1.2.3"}).Info(t.Context(), "default")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger", Version: "v1.2.3"}]
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(records))
+	}
+	startup := records[0]
+	if got := startup.Body.AsString(); got != startupMsg {
+		t.Errorf("got body %q, want %q", got, startupMsg)
+	}
+	if startup.Severity != log.SeverityInfo {
+		t.Errorf("got severity %v, want %v", startup.Severity, log.SeverityInfo)
+	}
+	want := []log.KeyValue{
+		log.String("logger.name", "test-logger"),
+		log.String("logger.version", "v1.2.3"),
+		log.String("logger.min_severity", "DEBUG"),
+	}
+	if !equalKeyValues(startup.Attributes, want) {
+		t.Errorf("got attributes %v, want %v", startup.Attributes, want)
+	}
+	for _, r := range records[1:] {
+		if r.Body.AsString() == startupMsg {
+			t.Errorf("unexpected startup record %v", r)
+		}
+	}
+}
+
+func TestLogger_EventMsg(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"}).With("service", "api")
+
+	ctx := t.Context()
+	logger.TraceEventMsg(ctx, "trace.event", "trace")
+	logger.DebugEventMsg(ctx, "debug.event", "debug")
+	logger.WithNamespace("user").InfoEventMsg(ctx, "login", "user logged in", log.String("id", "u1"))
+	logger.WarnEventMsg(ctx, "warn.event", "warn")
+	logger.ErrorEventMsg(ctx, "error.event", "error")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	if len(records) != 5 {
+		t.Fatalf("expected 5 records, got %d", len(records))
+	}
+	info := records[2]
+	if info.EventName != "user.login" {
+		t.Errorf("got event name %q, want %q", info.EventName, "user.login")
+	}
+	if got := info.Body.AsString(); got != "user logged in" {
+		t.Errorf("got body %q, want %q", got, "user logged in")
+	}
+	if info.Severity != log.SeverityInfo {
+		t.Errorf("got severity %v, want %v", info.Severity, log.SeverityInfo)
+	}
+	want := []log.KeyValue{log.String("service", "api"), log.String("user.id", "u1")}
+	if !equalKeyValues(info.Attributes, want) {
+		t.Errorf("got attributes %v, want %v", info.Attributes, want)
+	}
+	for i, level := range []log.Severity{log.SeverityTrace, log.SeverityDebug, log.SeverityInfo, log.SeverityWarn, log.SeverityError} {
+		if records[i].Severity != level {
+			t.Errorf("record %d: got severity %v, want %v", i, records[i].Severity, level)
+		}
+		if records[i].EventName == "" || records[i].Body.Kind() != log.KindString {
+			t.Errorf("record %d: got event name %q and body %v, want both", i, records[i].EventName, records[i].Body)
+		}
+	}
+}
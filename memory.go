@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// MemorySink stores the log records emitted by the Logger returned by NewMemoryLogger.
+// It is safe for concurrent use.
+type MemorySink struct {
+	mu      sync.Mutex
+	records []log.Record
+}
+
+// NewMemoryLogger returns a Logger storing the emitted log records in the returned MemorySink.
+// It is intended for tests asserting on specific log records.
+func NewMemoryLogger() (*Logger, *MemorySink) {
+	sink := &MemorySink{}
+	provider := funcProvider{emit: func(_ context.Context, record log.Record) {
+		sink.mu.Lock()
+		defer sink.mu.Unlock()
+		sink.records = append(sink.records, record.Clone())
+	}}
+	return New(Options{Provider: provider, Name: "github.com/pellared/olog/memory"}), sink
+}
+
+// Records returns all stored log records in the order they were emitted.
+func (s *MemorySink) Records() []log.Record {
+	return s.filter(func(log.Record) bool { return true })
+}
+
+// WithSeverity returns the stored log records with the given severity.
+func (s *MemorySink) WithSeverity(level log.Severity) []log.Record {
+	return s.filter(func(r log.Record) bool { return r.Severity() == level })
+}
+
+// WithAttr returns the stored log records having an attribute with the given key and value.
+func (s *MemorySink) WithAttr(key string, value log.Value) []log.Record {
+	return s.filter(func(r log.Record) bool {
+		found := false
+		r.WalkAttributes(func(kv log.KeyValue) bool {
+			found = kv.Key == key && kv.Value.Equal(value)
+			return !found
+		})
+		return found
+	})
+}
+
+// filter returns the stored log records for which keep returns true.
+func (s *MemorySink) filter(keep func(log.Record) bool) []log.Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var records []log.Record
+	for _, r := range s.records {
+		if keep(r) {
+			records = append(records, r.Clone())
+		}
+	}
+	return records
+}
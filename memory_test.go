@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+func TestNewMemoryLogger(t *testing.T) {
+	logger, sink := NewMemoryLogger()
+
+	ctx := t.Context()
+	logger.Info(ctx, "started", "component", "db")
+	logger.Error(ctx, "failed", "component", "db")
+	logger.Error(ctx, "failed", "component", "cache")
+
+	bodies := func(records []log.Record) []string {
+		var got []string
+		for _, r := range records {
+			got = append(got, r.Body().AsString()+"/"+r.Severity().String())
+		}
+		return got
+	}
+
+	assertBodies := func(name string, records []log.Record, want ...string) {
+		t.Helper()
+		got := bodies(records)
+		if len(got) != len(want) {
+			t.Fatalf("%s: got %v, want %v", name, got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%s: got %v, want %v", name, got, want)
+			}
+		}
+	}
+
+	assertBodies("Records", sink.Records(), "started/INFO", "failed/ERROR", "failed/ERROR")
+	assertBodies("WithSeverity", sink.WithSeverity(log.SeverityError), "failed/ERROR", "failed/ERROR")
+	assertBodies("WithAttr", sink.WithAttr("component", log.StringValue("db")), "started/INFO", "failed/ERROR")
+	assertBodies("WithAttr missing", sink.WithAttr("component", log.StringValue("queue")))
+}
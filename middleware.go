@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// EmitFunc emits a log record.
+type EmitFunc func(ctx context.Context, r log.Record)
+
+// Middleware wraps the emission of log records, for example to drop,
+// modify, or delay them. It returns an EmitFunc that passes the records
+// on to next.
+type Middleware func(next EmitFunc) EmitFunc
+
+// emitChain identifies the middleware chain of a logger for the middleware
+// holding back log records, so that the records of different loggers
+// sharing the middleware are never merged.
+type emitChain struct {
+	next EmitFunc
+}
+
+// ticker is the clock of the middleware and loggers summarizing
+// the log records periodically by a background goroutine.
+type ticker struct {
+	now func() time.Time
+	// start returns the channel delivering the ticks every d
+	// and the function stopping them.
+	start func(d time.Duration) (<-chan time.Time, func())
+}
+
+// systemTicker is the ticker of the system clock.
+var systemTicker = ticker{
+	now: time.Now,
+	start: func(d time.Duration) (<-chan time.Time, func()) {
+		t := time.NewTicker(d)
+		return t.C, t.Stop
+	},
+}
+
+// chainMiddleware returns the EmitFunc passing the records through the
+// middleware of cfg to the Emit method of logger, the first middleware being
+// the outermost, and then to the hooks of cfg, see config.emitHooks.
+// It returns nil if there is neither middleware nor hooks.
+func chainMiddleware(cfg *config, logger log.Logger, name string) EmitFunc {
+	next := cfg.emitHooks(logger, name)
+	if len(cfg.middleware) == 0 {
+		return next
+	}
+	if next == nil {
+		next = logger.Emit
+	}
+	for i := len(cfg.middleware) - 1; i >= 0; i-- {
+		next = cfg.middleware[i](next)
+	}
+	return next
+}
+
+// withOuter returns a new Logger emitting the log records through mw,
+// which wraps the emission of l. Unlike Options.Middleware, mw is only
+// applied to the returned logger and the loggers derived from it,
+// including the ones derived with Named.
+func (l *Logger) withOuter(mw Middleware) *Logger {
+	c := l.clone()
+	c.outer = append(slices.Clip(l.outer), mw)
+	c.emitFn = mw(l.emitFunc())
+	return c
+}
+
+// emitFunc returns the EmitFunc emitting the log records of l.
+func (l *Logger) emitFunc() EmitFunc {
+	if l.emitFn != nil {
+		return l.emitFn
+	}
+	return l.Emit
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+)
+
+func tagMiddleware(key string) Middleware {
+	return func(next EmitFunc) EmitFunc {
+		return func(ctx context.Context, r log.Record) {
+			r.AddAttributes(log.Bool(key, true))
+			next(ctx, r)
+		}
+	}
+}
+
+func TestOptions_Middleware(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	dropDebug := func(next EmitFunc) EmitFunc {
+		return func(ctx context.Context, r log.Record) {
+			if r.Severity() >= log.SeverityInfo {
+				next(ctx, r)
+			}
+		}
+	}
+	logger := New(Options{
+		Provider:   recorder,
+		Name:       "test-logger",
+		Middleware: []Middleware{tagMiddleware("outer"), dropDebug, tagMiddleware("inner")},
+	})
+
+	ctx := t.Context()
+	logger.Debug(ctx, "dropped")
+	logger.Info(ctx, "kept")
+	logger.Named("sub").Info(ctx, "named")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	require.Len(t, records, 1)
+	assert.Equal(t, log.StringValue("kept"), records[0].Body)
+	assert.Equal(t, []log.KeyValue{log.Bool("outer", true), log.Bool("inner", true)}, records[0].Attributes)
+
+	named := recorder.Result()[logtest.Scope{Name: "test-logger.sub"}]
+	require.Len(t, named, 1)
+	assert.Equal(t, []log.KeyValue{log.Bool("outer", true), log.Bool("inner", true)}, named[0].Attributes)
+}
+
+func TestOptions_MiddlewareHooks(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)}
+	tk, _ := clock.newTicker()
+
+	var tapped []string
+	throttle := newTestThrottle(t, time.Minute, tk)
+	logger := New(Options{
+		Provider:   logtest.NewRecorder(),
+		Name:       "test-logger",
+		Middleware: []Middleware{throttle.Middleware},
+		Tap: func(_ context.Context, r log.Record) {
+			tapped = append(tapped, r.Body().AsString())
+		},
+		TrackLastEmitted: true,
+	})
+
+	ctx := t.Context()
+	logger.Info(ctx, "first")
+	logger.Info(ctx, "first")
+	logger.Info(ctx, "first")
+
+	// The duplicates suppressed by the middleware are not emitted.
+	assert.Equal(t, []string{"first"}, tapped)
+	last, ok := logger.LastEmitted()
+	require.True(t, ok)
+	assert.Zero(t, last.AttributesLen())
+
+	require.NoError(t, throttle.Close())
+
+	// The summary emitted by the middleware is.
+	assert.Equal(t, []string{"first", "first"}, tapped)
+	last, ok = logger.LastEmitted()
+	require.True(t, ok)
+	assert.Equal(t, 1, last.AttributesLen())
+}
@@ -48,3 +48,67 @@ func TestLogger_DefaultName(t *testing.T) {
 		return r
 	}))
 }
+
+func TestOptions_NameAsAttr(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := olog.New(olog.Options{Provider: recorder, Name: "my-component", NameAsAttr: "logger.name"})
+
+	ctx := t.Context()
+	logger.Info(ctx, "message", "key", "value")
+	logger.Named("sub").InfoEvent(ctx, "event")
+
+	want := logtest.Recording{
+		logtest.Scope{Name: "my-component"}: {
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityInfo,
+				Body:     log.StringValue("message"),
+				Attributes: []log.KeyValue{
+					log.String("key", "value"),
+					log.String("logger.name", "my-component"),
+				},
+			},
+		},
+		logtest.Scope{Name: "my-component.sub"}: {
+			logtest.Record{
+				Context:    ctx,
+				Severity:   log.SeverityInfo,
+				EventName:  "event",
+				Attributes: []log.KeyValue{log.String("logger.name", "my-component.sub")},
+			},
+		},
+	}
+
+	got := recorder.Result()
+	logtest.AssertEqual(t, want, got, logtest.Transform(func(r logtest.Record) logtest.Record {
+		r.Timestamp = time.Time{}
+		r.ObservedTimestamp = time.Time{}
+		return r
+	}))
+}
+
+func TestOptions_NameAsAttrDetected(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := olog.New(olog.Options{Provider: recorder, NameAsAttr: "logger.name"})
+
+	ctx := t.Context()
+	logger.Info(ctx, "message")
+
+	want := logtest.Recording{
+		logtest.Scope{Name: "github.com/pellared/olog_test"}: {
+			logtest.Record{
+				Context:    ctx,
+				Severity:   log.SeverityInfo,
+				Body:       log.StringValue("message"),
+				Attributes: []log.KeyValue{log.String("logger.name", "github.com/pellared/olog_test")},
+			},
+		},
+	}
+
+	got := recorder.Result()
+	logtest.AssertEqual(t, want, got, logtest.Transform(func(r logtest.Record) logtest.Record {
+		r.Timestamp = time.Time{}
+		r.ObservedTimestamp = time.Time{}
+		return r
+	}))
+}
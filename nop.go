@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/noop"
+)
+
+// nopLogger is the shared logger that emits nothing.
+// Its minimum severity is above all severities, so log records are dropped
+// before they reach the underlying no-op logger.
+var nopLogger = func() *Logger {
+	cfg := &config{provider: noop.NewLoggerProvider()}
+	cfg.filters.Store(&filters{minSeverity: log.SeverityFatal4 + 1})
+	return &Logger{Logger: noop.Logger{}, cfg: cfg}
+}()
+
+// Discard is a logger that emits nothing.
+// The loggers derived from it with With, WithAttr, and WithFields are Discard itself,
+// so deriving them allocates nothing.
+var Discard = nopLogger
+
+// IsNoop reports whether the logger is known to emit nothing,
+// which is the case for Discard and the loggers derived from it.
+func (l *Logger) IsNoop() bool {
+	return l.cfg == nopLogger.cfg
+}
+
+// IfTrace returns the logger if it emits trace-level log records
+// and a shared logger that emits nothing otherwise.
+// It guards a block of log calls with a single check:
+//
+//	dbg := logger.IfTrace(ctx)
+//	dbg.Trace(ctx, "step 1")
+//	dbg.Trace(ctx, "step 2")
+func (l *Logger) IfTrace(ctx context.Context) *Logger {
+	if l.TraceEnabled(ctx) {
+		return l
+	}
+	return nopLogger
+}
+
+// IfDebug returns the logger if it emits debug-level log records
+// and a shared logger that emits nothing otherwise.
+func (l *Logger) IfDebug(ctx context.Context) *Logger {
+	if l.DebugEnabled(ctx) {
+		return l
+	}
+	return nopLogger
+}
+
+// IfInfo returns the logger if it emits info-level log records
+// and a shared logger that emits nothing otherwise.
+func (l *Logger) IfInfo(ctx context.Context) *Logger {
+	if l.InfoEnabled(ctx) {
+		return l
+	}
+	return nopLogger
+}
+
+// IfWarn returns the logger if it emits warn-level log records
+// and a shared logger that emits nothing otherwise.
+func (l *Logger) IfWarn(ctx context.Context) *Logger {
+	if l.WarnEnabled(ctx) {
+		return l
+	}
+	return nopLogger
+}
+
+// IfError returns the logger if it emits error-level log records
+// and a shared logger that emits nothing otherwise.
+func (l *Logger) IfError(ctx context.Context) *Logger {
+	if l.ErrorEnabled(ctx) {
+		return l
+	}
+	return nopLogger
+}
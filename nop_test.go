@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+)
+
+func TestLogger_IfLevel(t *testing.T) {
+	recorder := logtest.NewRecorder(logtest.WithEnabledFunc(func(_ context.Context, p log.EnabledParameters) bool {
+		return p.Severity >= log.SeverityInfo
+	}))
+	logger := New(Options{Provider: recorder, Name: "test-logger"})
+
+	ctx := t.Context()
+	assert.Same(t, nopLogger, logger.IfTrace(ctx))
+	assert.Same(t, nopLogger, logger.IfDebug(ctx))
+	assert.Same(t, logger, logger.IfInfo(ctx))
+	assert.Same(t, logger, logger.IfWarn(ctx))
+	assert.Same(t, logger, logger.IfError(ctx))
+
+	logger.IfDebug(ctx).Debug(ctx, "disabled")
+	logger.IfDebug(ctx).With("key", "value").ErrorEvent(ctx, "disabled")
+	logger.IfInfo(ctx).Info(ctx, "enabled")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	if assert.Len(t, records, 1) {
+		assert.Equal(t, log.StringValue("enabled"), records[0].Body)
+	}
+}
+
+func TestNopLogger(t *testing.T) {
+	ctx := t.Context()
+	for _, level := range []log.Severity{log.SeverityTrace1, log.SeverityInfo, log.SeverityFatal4} {
+		assert.False(t, nopLogger.WillEmit(ctx, level), level.String())
+	}
+	assert.NoError(t, nopLogger.Flush(ctx))
+}
+
+func TestDiscard(t *testing.T) {
+	ctx := t.Context()
+	assert.True(t, Discard.IsNoop())
+	assert.True(t, Discard.WithNamespace("ns").IsNoop())
+	assert.False(t, New(Options{Provider: logtest.NewRecorder(), Name: "test-logger"}).IsNoop())
+
+	assert.Same(t, Discard, Discard.With("key", "value"))
+	assert.Same(t, Discard, Discard.WithNamespace("ns").WithAttr(log.String("key", "value")))
+	assert.False(t, Discard.With("key", "value").WillEmit(ctx, log.SeverityFatal4))
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = Discard.With("key", "value", "n", 1).WithAttr(log.String("key", "value"))
+	})
+	assert.Zero(t, allocs)
+}
+
+func TestDiscard_WithAttrsLazy(t *testing.T) {
+	fn := func() []log.KeyValue { return []log.KeyValue{log.String("key", "value")} }
+
+	assert.Same(t, Discard, Discard.WithAttrsLazy(fn))
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = Discard.WithAttrsLazy(fn)
+	})
+	assert.Zero(t, allocs)
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// Observe logs the name+".start" info event and returns a function that logs
+// the name+".stop" info event with the duration_ms attribute, the elapsed time
+// in milliseconds, and the error attribute if the passed error is not nil.
+// The time is read like in StartOp.
+//
+//	func query(ctx context.Context) (err error) {
+//		stop := logger.Observe(ctx, "db.query")
+//		defer func() { stop(err) }()
+//		...
+//	}
+func (l *Logger) Observe(ctx context.Context, name string) func(err error) {
+	start := l.clockNow()
+	l.logEventAttr(ctx, log.SeverityInfo, name+".start", nil)
+	return func(err error) {
+		attrs := []log.KeyValue{
+			log.Float64("duration_ms", float64(l.clockNow().Sub(start))/float64(time.Millisecond)),
+		}
+		if err != nil {
+			attrs = append(attrs, log.String(errorKey, err.Error()))
+		}
+		l.logEventAttr(ctx, log.SeverityInfo, name+".stop", attrs)
+	}
+}
+
+// WithSlowWarning returns a new Logger whose operations started with StartOp
+// are logged with the warn severity if they last longer than threshold.
+// If threshold is zero, they are always logged with the info severity.
+func (l *Logger) WithSlowWarning(threshold time.Duration) *Logger {
+	c := l.clone()
+	c.slowThreshold = threshold
+	return c
+}
+
+// StartOp returns a function that logs the name event with the duration_ms
+// attribute, the time elapsed since StartOp was called in milliseconds.
+// The event is logged with the warn severity if the operation is slower than
+// the threshold set with WithSlowWarning and with the info severity otherwise.
+// The time is read from the clock of the logger if one is set, see WithClock,
+// and otherwise measured with the monotonic clock like with time.Since.
+//
+//	done := logger.WithSlowWarning(time.Second).StartOp(ctx, "cache.refresh")
+//	defer done()
+func (l *Logger) StartOp(ctx context.Context, name string) func() {
+	// The monotonic clock reading stripped by now is kept,
+	// so that the duration is not affected by wall clock changes.
+	start := l.clockNow()
+	return func() {
+		elapsed := l.clockNow().Sub(start)
+		level := log.SeverityInfo
+		if l.slowThreshold > 0 && elapsed > l.slowThreshold {
+			level = log.SeverityWarn
+		}
+		l.logEventAttr(ctx, level, name, []log.KeyValue{
+			log.Float64("duration_ms", float64(elapsed)/float64(time.Millisecond)),
+		})
+	}
+}
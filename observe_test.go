@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+)
+
+func TestLogger_Observe(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)}
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger", Now: clock.Now}).With("service", "api")
+
+	ctx := t.Context()
+	stop := logger.Observe(ctx, "db.query")
+	clock.Advance(2500 * time.Microsecond)
+	stop(nil)
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	require.Len(t, records, 2)
+
+	assert.Equal(t, "db.query.start", records[0].EventName)
+	assert.Equal(t, log.SeverityInfo, records[0].Severity)
+	assert.Equal(t, []log.KeyValue{log.String("service", "api")}, records[0].Attributes)
+
+	assert.Equal(t, "db.query.stop", records[1].EventName)
+	assert.Equal(t, log.SeverityInfo, records[1].Severity)
+	assert.Equal(t, []log.KeyValue{
+		log.String("service", "api"),
+		log.Float64("duration_ms", 2.5),
+	}, records[1].Attributes)
+}
+
+func TestLogger_ObserveError(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"})
+
+	ctx := t.Context()
+	stop := logger.Observe(ctx, "db.query")
+	stop(errors.New("timeout"))
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	require.Len(t, records, 2)
+	stopAttrs := records[1].Attributes
+	require.Len(t, stopAttrs, 2)
+	assert.Equal(t, "duration_ms", stopAttrs[0].Key)
+	assert.Equal(t, log.String("error", "timeout"), stopAttrs[1])
+}
+
+func TestLogger_StartOp(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)}
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger", Now: clock.Now}).WithSlowWarning(time.Second)
+
+	ctx := t.Context()
+	done := logger.StartOp(ctx, "fast")
+	clock.Advance(500 * time.Millisecond)
+	done()
+
+	done = logger.StartOp(ctx, "slow")
+	clock.Advance(1500 * time.Millisecond)
+	done()
+
+	done = logger.WithSlowWarning(0).StartOp(ctx, "unbounded")
+	clock.Advance(time.Hour)
+	done()
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	require.Len(t, records, 3)
+
+	assert.Equal(t, "fast", records[0].EventName)
+	assert.Equal(t, log.SeverityInfo, records[0].Severity)
+	assert.Equal(t, []log.KeyValue{log.Float64("duration_ms", 500)}, records[0].Attributes)
+
+	assert.Equal(t, "slow", records[1].EventName)
+	assert.Equal(t, log.SeverityWarn, records[1].Severity)
+	assert.Equal(t, []log.KeyValue{log.Float64("duration_ms", 1500)}, records[1].Attributes)
+
+	assert.Equal(t, "unbounded", records[2].EventName)
+	assert.Equal(t, log.SeverityInfo, records[2].Severity)
+}
+
+func TestLogger_StartOpMonotonic(t *testing.T) {
+	logger := New(Options{Provider: logtest.NewRecorder(), Name: "test-logger"})
+
+	// The durations are measured with the monotonic clock
+	// even if it is stripped from the timestamps.
+	assert.Contains(t, logger.clockNow().String(), "m=")
+	assert.NotContains(t, logger.now().String(), "m=")
+}
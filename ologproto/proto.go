@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ologproto provides helpers for logging protocol buffer messages
+// with olog. It is a separate package so that the olog package does not
+// depend on google.golang.org/protobuf.
+package ologproto // import "github.com/pellared/olog/ologproto"
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// ProtoAttrs returns an attribute with the message m as a structured value,
+// so that its fields can be queried instead of being logged as a %v string:
+//
+//	logger.InfoAttr(ctx, "request received", ologproto.ProtoAttrs("request", req))
+//
+// The value is converted from the protojson representation of m.
+// Messages are represented as maps with the JSON field names as keys,
+// repeated fields as slices, and numbers as integers when they are whole.
+// Note that protojson represents 64-bit integers as strings and
+// some well-known types, such as google.protobuf.Timestamp, as strings.
+//
+// A nil message is logged as an empty value. If m cannot be marshaled,
+// the value is a map with the error attribute.
+func ProtoAttrs(key string, m proto.Message) log.KeyValue {
+	return log.KeyValue{Key: key, Value: protoValue(m)}
+}
+
+func protoValue(m proto.Message) log.Value {
+	if m == nil || !m.ProtoReflect().IsValid() {
+		return log.Value{}
+	}
+	b, err := protojson.Marshal(m)
+	if err != nil {
+		return log.MapValue(log.String("error", err.Error()))
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return log.MapValue(log.String("error", err.Error()))
+	}
+	return jsonValue(v)
+}
+
+// jsonValue converts a value decoded from JSON with json.Decoder.UseNumber.
+func jsonValue(v any) log.Value {
+	switch v := v.(type) {
+	case bool:
+		return log.BoolValue(v)
+	case string:
+		return log.StringValue(v)
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return log.Int64Value(i)
+		}
+		if f, err := v.Float64(); err == nil {
+			return log.Float64Value(f)
+		}
+		return log.StringValue(v.String())
+	case []any:
+		items := make([]log.Value, len(v))
+		for i, item := range v {
+			items[i] = jsonValue(item)
+		}
+		return log.SliceValue(items...)
+	case map[string]any:
+		// The keys are sorted as the order of map iteration is random.
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		kvs := make([]log.KeyValue, len(keys))
+		for i, k := range keys {
+			kvs[i] = log.KeyValue{Key: k, Value: jsonValue(v[k])}
+		}
+		return log.MapValue(kvs...)
+	default:
+		return log.Value{}
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ologproto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+func TestProtoAttrs(t *testing.T) {
+	msg := &descriptorpb.DescriptorProto{
+		Name: proto.String("User"),
+		Field: []*descriptorpb.FieldDescriptorProto{
+			{
+				Name:   proto.String("id"),
+				Number: proto.Int32(1),
+				Type:   descriptorpb.FieldDescriptorProto_TYPE_INT64.Enum(),
+			},
+			{
+				Name:     proto.String("email"),
+				Number:   proto.Int32(2),
+				JsonName: proto.String("email"),
+			},
+		},
+	}
+
+	for _, tt := range []struct {
+		name string
+		msg  proto.Message
+		want log.Value
+	}{
+		{
+			name: "message",
+			msg:  msg,
+			want: log.MapValue(
+				log.Slice("field",
+					log.MapValue(
+						log.String("name", "id"),
+						log.Int64("number", 1),
+						log.String("type", "TYPE_INT64"),
+					),
+					log.MapValue(
+						log.String("jsonName", "email"),
+						log.String("name", "email"),
+						log.Int64("number", 2),
+					),
+				),
+				log.String("name", "User"),
+			),
+		},
+		{
+			name: "struct",
+			msg: func() proto.Message {
+				s, err := structpb.NewStruct(map[string]any{"ok": true, "ratio": 0.5, "tags": []any{"a"}})
+				if err != nil {
+					t.Fatal(err)
+				}
+				return s
+			}(),
+			want: log.MapValue(
+				log.Bool("ok", true),
+				log.Float64("ratio", 0.5),
+				log.Slice("tags", log.StringValue("a")),
+			),
+		},
+		{
+			name: "empty message",
+			msg:  &descriptorpb.DescriptorProto{},
+			want: log.MapValue(),
+		},
+		{
+			name: "well-known wrapper",
+			msg:  wrapperspb.Int32(7),
+			want: log.Int64Value(7),
+		},
+		{
+			name: "nil",
+			msg:  nil,
+			want: log.Value{},
+		},
+		{
+			name: "typed nil",
+			msg:  (*descriptorpb.DescriptorProto)(nil),
+			want: log.Value{},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ProtoAttrs("msg", tt.msg)
+			assert.Equal(t, "msg", got.Key)
+			assert.True(t, tt.want.Equal(got.Value), "got %v, want %v", got.Value, tt.want)
+		})
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ologresource provides helpers for using the OpenTelemetry SDK
+// resources with olog. It is a separate package so that the olog package
+// does not depend on go.opentelemetry.io/otel/sdk.
+package ologresource // import "github.com/pellared/olog/ologresource"
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// Attributes returns attrs merged with the attributes of res, to be used as
+// olog.Options.Attributes, the instrumentation scope attributes of the logger.
+// The attributes of attrs take precedence on conflicting keys:
+//
+//	logger := olog.New(olog.Options{
+//		Attributes: ologresource.Attributes(res, attribute.NewSet(attribute.String("service.name", "api"))),
+//	})
+func Attributes(res *resource.Resource, attrs attribute.Set) attribute.Set {
+	if res.Len() == 0 {
+		return attrs
+	}
+	// A set keeps the last of duplicate keys, so attrs takes precedence.
+	return attribute.NewSet(append(res.Attributes(), attrs.ToSlice()...)...)
+}
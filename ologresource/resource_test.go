@@ -0,0 +1,37 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ologresource
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+func TestAttributes(t *testing.T) {
+	res := resource.NewSchemaless(
+		attribute.String("service.name", "from-resource"),
+		attribute.String("host.name", "node-1"),
+	)
+	attrs := attribute.NewSet(attribute.String("service.name", "explicit"))
+
+	want := attribute.NewSet(
+		attribute.String("service.name", "explicit"),
+		attribute.String("host.name", "node-1"),
+	)
+	got := Attributes(res, attrs)
+	assert.Equal(t, want.ToSlice(), got.ToSlice())
+}
+
+func TestAttributes_EmptyResource(t *testing.T) {
+	attrs := attribute.NewSet(attribute.String("service.name", "explicit"))
+
+	got := Attributes(nil, attrs)
+	assert.Equal(t, attrs.ToSlice(), got.ToSlice())
+	got = Attributes(resource.Empty(), attrs)
+	assert.Equal(t, attrs.ToSlice(), got.ToSlice())
+}
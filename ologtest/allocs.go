@@ -0,0 +1,23 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ologtest provides helpers for testing the use of olog.
+package ologtest // import "github.com/pellared/olog/ologtest"
+
+import "testing"
+
+// allocsRuns is the number of times MeasureAllocs calls the function.
+const allocsRuns = 100
+
+// MeasureAllocs returns the average number of heap allocations made by fn,
+// for example a single log call, to compare the allocations of call styles:
+//
+//	func TestLogAllocs(t *testing.T) {
+//		t.Log("variadic", ologtest.MeasureAllocs(func() { logger.Info(ctx, "msg", "n", n) }))
+//		t.Log("attr", ologtest.MeasureAllocs(func() { logger.InfoAttr(ctx, "msg", log.Int("n", n)) }))
+//	}
+//
+// It wraps testing.AllocsPerRun, so it must not be called from parallel tests.
+func MeasureAllocs(fn func()) float64 {
+	return testing.AllocsPerRun(allocsRuns, fn)
+}
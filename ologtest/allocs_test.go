@@ -0,0 +1,29 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ologtest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/log"
+
+	"github.com/pellared/olog"
+)
+
+func TestMeasureAllocs(t *testing.T) {
+	ctx := t.Context()
+
+	assert.Zero(t, MeasureAllocs(func() {
+		olog.Discard.Info(ctx, "msg")
+		olog.Discard.DebugAttr(ctx, "msg", log.Int("n", 1))
+	}))
+
+	var sink []byte
+	assert.Equal(t, 1.0, MeasureAllocs(func() {
+		sink = make([]byte, 1024)
+	}))
+	_ = sink
+}
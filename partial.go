@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// Partial accumulates the attributes of a log record that is logged once
+// by Commit or CommitErr. It is safe for concurrent use.
+type Partial struct {
+	l     *Logger
+	ctx   context.Context
+	level log.Severity
+	msg   string
+
+	mu        sync.Mutex
+	attrs     []log.KeyValue
+	committed bool
+}
+
+// Partial returns a Partial accumulating the attributes of a log record with
+// the given severity and message, for a function gathering context incrementally:
+//
+//	p := logger.Partial(ctx, log.SeverityInfo, "order processed")
+//	p.Add(log.String("order.id", id))
+//	...
+//	p.Add(log.Int("items", n))
+//	p.Commit()
+//
+// Nothing is logged if the Partial is never committed.
+func (l *Logger) Partial(ctx context.Context, level log.Severity, msg string) *Partial {
+	return &Partial{l: l, ctx: ctx, level: level, msg: msg}
+}
+
+// Add adds attributes to the log record.
+// It does nothing once the record is committed.
+func (p *Partial) Add(attrs ...log.KeyValue) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.committed {
+		p.attrs = append(p.attrs, attrs...)
+	}
+}
+
+// Commit logs the log record with all added attributes.
+// Only the first call of Commit or CommitErr logs the record.
+func (p *Partial) Commit() {
+	p.CommitErr(nil)
+}
+
+// CommitErr logs the log record like Commit, with the error attribute
+// added if err is not nil.
+func (p *Partial) CommitErr(err error) {
+	p.mu.Lock()
+	if p.committed {
+		p.mu.Unlock()
+		return
+	}
+	p.committed = true
+	attrs := p.attrs
+	p.attrs = nil
+	p.mu.Unlock()
+
+	if err != nil {
+		attrs = append(attrs, log.String(errorKey, err.Error()))
+	}
+	p.l.logAttr(p.ctx, p.level, p.msg, attrs)
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+)
+
+func TestLogger_Partial(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"}).With("service", "api")
+
+	p := logger.Partial(t.Context(), log.SeverityInfo, "order processed")
+	p.Add(log.String("order.id", "o1"))
+	p.Add()
+	p.Add(log.Int("items", 3), log.Bool("paid", true))
+	assert.Empty(t, recorder.Result()[logtest.Scope{Name: "test-logger"}], "nothing is logged before Commit")
+	p.Commit()
+	p.Add(log.String("late", "ignored"))
+	p.Commit()
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	require.Len(t, records, 1)
+	assert.Equal(t, log.SeverityInfo, records[0].Severity)
+	assert.Equal(t, log.StringValue("order processed"), records[0].Body)
+	assert.Equal(t, []log.KeyValue{
+		log.String("service", "api"),
+		log.String("order.id", "o1"),
+		log.Int("items", 3),
+		log.Bool("paid", true),
+	}, records[0].Attributes)
+}
+
+func TestPartial_CommitErr(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"})
+
+	p := logger.Partial(t.Context(), log.SeverityError, "order failed")
+	p.Add(log.String("order.id", "o1"))
+	p.CommitErr(errors.New("out of stock"))
+	p.Commit()
+
+	logger.Partial(t.Context(), log.SeverityInfo, "never committed").Add(log.Int("n", 1))
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	require.Len(t, records, 1)
+	assert.Equal(t, log.StringValue("order failed"), records[0].Body)
+	assert.Equal(t, []log.KeyValue{
+		log.String("order.id", "o1"),
+		log.String("error", "out of stock"),
+	}, records[0].Attributes)
+}
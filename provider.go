@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/embedded"
+)
+
+// funcProvider is a LoggerProvider whose loggers pass all emitted records to a function.
+type funcProvider struct {
+	embedded.LoggerProvider
+
+	emit func(ctx context.Context, record log.Record)
+}
+
+// Logger returns a logger passing all emitted records to the provider's function.
+func (p funcProvider) Logger(string, ...log.LoggerOption) log.Logger {
+	return funcLogger{emit: p.emit}
+}
+
+// funcLogger is a Logger passing all emitted records to a function.
+type funcLogger struct {
+	embedded.Logger
+
+	emit func(ctx context.Context, record log.Record)
+}
+
+// Emit passes the record to the logger's function.
+func (l funcLogger) Emit(ctx context.Context, record log.Record) {
+	l.emit(ctx, record)
+}
+
+// Enabled returns true.
+func (funcLogger) Enabled(context.Context, log.EnabledParameters) bool {
+	return true
+}
+
+// NewChannelLogger returns a Logger that sends a copy of each emitted log record
+// to the returned channel with the given buffer size.
+// Records are dropped when the channel buffer is full.
+// It is intended for tests consuming log records as they are emitted.
+func NewChannelLogger(buffer int) (*Logger, <-chan log.Record) {
+	ch := make(chan log.Record, buffer)
+	provider := funcProvider{emit: func(_ context.Context, record log.Record) {
+		select {
+		case ch <- record.Clone():
+		default:
+		}
+	}}
+	return New(Options{Provider: provider, Name: "github.com/pellared/olog/channel"}), ch
+}
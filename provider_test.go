@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+func TestNewChannelLogger(t *testing.T) {
+	logger, records := NewChannelLogger(2)
+
+	ctx := t.Context()
+	logger.Info(ctx, "first", "n", 1)
+	logger.Warn(ctx, "second", "n", 2)
+	logger.Error(ctx, "dropped", "n", 3)
+
+	for i, want := range []struct {
+		body     string
+		severity log.Severity
+	}{
+		{body: "first", severity: log.SeverityInfo},
+		{body: "second", severity: log.SeverityWarn},
+	} {
+		r := <-records
+		if got := r.Body().AsString(); got != want.body {
+			t.Errorf("record %d: got body %q, want %q", i, got, want.body)
+		}
+		if got := r.Severity(); got != want.severity {
+			t.Errorf("record %d: got severity %v, want %v", i, got, want.severity)
+		}
+	}
+
+	select {
+	case r := <-records:
+		t.Errorf("expected record to be dropped when the buffer is full, got %q", r.Body().AsString())
+	default:
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+const (
+	// rateSummaryMsg is the body of the log records emitted by NewRateSummary.
+	rateSummaryMsg = "log rate summary"
+	// countsKey is the attribute key of the counts per severity emitted by NewRateSummary.
+	countsKey = "counts"
+)
+
+// NewRateSummary returns a logger derived from l which counts the emitted log
+// records per severity. Every interval, a summary info record with the counts
+// attribute is emitted via l and the counts are reset. The counts attribute is
+// a map from the severity, for example "INFO", to the number of records
+// emitted with it since the previous summary. The returned function stops
+// the summaries and must be called when the logger is no longer used:
+//
+//	logger, stop := olog.NewRateSummary(logger, time.Minute)
+//	defer stop()
+//
+// The records dropped by the minimum severity or the sampler are not counted.
+func NewRateSummary(l *Logger, interval time.Duration) (*Logger, func()) {
+	return newRateSummary(l, interval, systemTicker)
+}
+
+// newRateSummary returns a logger counting the records summarized on the ticks of tk,
+// see NewRateSummary.
+func newRateSummary(l *Logger, interval time.Duration, tk ticker) (*Logger, func()) {
+	var (
+		mu     sync.Mutex
+		counts = make(map[log.Severity]int64)
+	)
+	c := l.withOuter(func(next EmitFunc) EmitFunc {
+		return func(ctx context.Context, r log.Record) {
+			mu.Lock()
+			counts[r.Severity()]++
+			mu.Unlock()
+			next(ctx, r)
+		}
+	})
+
+	ticks, stopTicker := tk.start(interval)
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticks:
+				mu.Lock()
+				attrs := rateCounts(counts)
+				clear(counts)
+				mu.Unlock()
+				l.InfoAttr(context.Background(), rateSummaryMsg, log.Map(countsKey, attrs...))
+			}
+		}
+	}()
+
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			stopTicker()
+			close(done)
+			<-stopped
+		})
+	}
+	return c, stop
+}
+
+// rateCounts returns counts as key-values sorted by the severity.
+func rateCounts(counts map[log.Severity]int64) []log.KeyValue {
+	sevs := make([]log.Severity, 0, len(counts))
+	for sev := range counts {
+		sevs = append(sevs, sev)
+	}
+	slices.Sort(sevs)
+	attrs := make([]log.KeyValue, len(sevs))
+	for i, sev := range sevs {
+		attrs[i] = log.Int64(sev.String(), counts[sev])
+	}
+	return attrs
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+)
+
+func TestNewRateSummary(t *testing.T) {
+	tk, ticks := (&fakeClock{}).newTicker()
+
+	recorder := logtest.NewRecorder()
+	base := New(Options{Provider: recorder, Name: "test-logger", MinSeverity: log.SeverityDebug})
+	logger, stop := newRateSummary(base, time.Minute, tk)
+
+	summaries := func() []logtest.Record {
+		var out []logtest.Record
+		for _, r := range recorder.Result()[logtest.Scope{Name: "test-logger"}] {
+			if r.Body.AsString() == rateSummaryMsg {
+				out = append(out, r)
+			}
+		}
+		return out
+	}
+
+	ctx := t.Context()
+	logger.Trace(ctx, "dropped")
+	logger.Info(ctx, "first")
+	logger.Error(ctx, "failed")
+	logger.Named("sub").Info(ctx, "second")
+	base.Warn(ctx, "not counted")
+
+	ticks <- time.Time{}
+	// The counts are reset before the summary is emitted.
+	require.Eventually(t, func() bool { return len(summaries()) == 1 }, time.Second, time.Millisecond)
+	logger.Debug(ctx, "third")
+	ticks <- time.Time{}
+	// The ticks received before stop returns are handled.
+	stop()
+
+	got := summaries()
+	require.Len(t, got, 2)
+	assert.Equal(t, log.SeverityInfo, got[0].Severity)
+	assert.Equal(t, []log.KeyValue{
+		log.Map(countsKey, log.Int64("INFO", 2), log.Int64("ERROR", 1)),
+	}, got[0].Attributes)
+	assert.Equal(t, []log.KeyValue{
+		log.Map(countsKey, log.Int64("DEBUG", 1)),
+	}, got[1].Attributes, "counts are reset after each summary")
+}
+
+func TestNewRateSummary_Stop(t *testing.T) {
+	tk, _ := (&fakeClock{}).newTicker()
+
+	recorder := logtest.NewRecorder()
+	logger, stop := newRateSummary(New(Options{Provider: recorder, Name: "test-logger"}), time.Minute, tk)
+	stop()
+	stop() // Stopping twice is a no-op.
+
+	logger.Info(t.Context(), "after stop")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	require.Len(t, records, 1)
+	assert.Equal(t, log.StringValue("after stop"), records[0].Body)
+}
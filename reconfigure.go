@@ -0,0 +1,44 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import (
+	"regexp"
+	"slices"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// filters holds the options that can be changed with Reconfigure.
+// It is immutable once stored in a config.
+type filters struct {
+	minSeverity  log.Severity
+	sampler      Sampler
+	maskPatterns []*regexp.Regexp
+}
+
+// newFilters returns the filters of options.
+func newFilters(options Options) *filters {
+	return &filters{
+		minSeverity:  options.MinSeverity,
+		sampler:      options.Sampler,
+		maskPatterns: slices.Clone(options.MaskPatterns),
+	}
+}
+
+// Reconfigure atomically replaces the filtering options of the logger with
+// MinSeverity, Sampler, and MaskPatterns of opts. The other fields of opts
+// are ignored. The underlying log.Logger and the attributes are not changed.
+//
+// The change is observed by all loggers created by the same New call,
+// the logger was created by or derived from, including the ones derived before.
+// The minimum severities and samplers set with WithMinSeverity and WithSampler
+// are kept. It is safe to call concurrently with logging.
+// It does nothing for a no-op logger, see IsNoop.
+func (l *Logger) Reconfigure(opts Options) {
+	if l.IsNoop() {
+		return
+	}
+	l.cfg.filters.Store(newFilters(opts))
+}
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+)
+
+func TestLogger_Reconfigure(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger", MinSeverity: log.SeverityWarn})
+	child := logger.With("child", true)
+
+	ctx := t.Context()
+	child.Info(ctx, "dropped")
+
+	child.Reconfigure(Options{
+		MinSeverity:  log.SeverityInfo,
+		MaskPatterns: []*regexp.Regexp{regexp.MustCompile(`secret-\w+`)},
+		Sampler: func(_ context.Context, _ log.Severity, eventName string) bool {
+			return eventName != "noisy"
+		},
+	})
+	logger.Info(ctx, "token secret-abc")
+	child.InfoEvent(ctx, "noisy")
+	child.Debug(ctx, "dropped")
+	logger.WithMinSeverity(log.SeverityError).Warn(ctx, "dropped")
+	child.WithSampler(nil).InfoEvent(ctx, "noisy")
+	child.Warn(ctx, "warn")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	require.Len(t, records, 3)
+	assert.Equal(t, log.StringValue("token ***"), records[0].Body)
+	assert.Equal(t, "noisy", records[1].EventName)
+	assert.Equal(t, log.StringValue("warn"), records[2].Body)
+}
+
+func TestLogger_ReconfigureConcurrent(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"})
+
+	ctx := t.Context()
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				logger.With("k", "v").Info(ctx, "msg secret-abc")
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := range 100 {
+			opts := Options{MinSeverity: log.SeverityInfo}
+			if i%2 == 0 {
+				opts.MinSeverity = log.SeverityWarn
+				opts.MaskPatterns = []*regexp.Regexp{regexp.MustCompile(`secret-\w+`)}
+			}
+			logger.Reconfigure(opts)
+		}
+	}()
+	wg.Wait()
+
+	for _, r := range recorder.Result()[logtest.Scope{Name: "test-logger"}] {
+		assert.Equal(t, log.StringValue("msg secret-abc"), r.Body, "the masked records are dropped by the minimum severity set with the mask")
+	}
+}
+
+func TestLogger_ReconfigureNoop(t *testing.T) {
+	before := nopLogger.cfg.filters.Load()
+
+	Discard.Reconfigure(Options{MinSeverity: log.SeverityTrace})
+	Discard.With("k", "v").Reconfigure(Options{MinSeverity: log.SeverityTrace})
+
+	assert.Same(t, before, nopLogger.cfg.filters.Load())
+	assert.False(t, Discard.WillEmit(t.Context(), log.SeverityFatal4))
+}
@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import (
+	"context"
+	"runtime/trace"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// TraceRegion starts a runtime/trace region named name, logs the name+".start"
+// debug event, and returns a function that ends the region and logs the
+// name+".stop" debug event with the duration_ms attribute, the elapsed time
+// in milliseconds. It ties the execution traces captured while profiling
+// to the logs:
+//
+//	defer logger.TraceRegion(ctx, "decode")()
+//
+// The returned function must be called from the goroutine that called TraceRegion.
+func (l *Logger) TraceRegion(ctx context.Context, name string) func() {
+	start := time.Now()
+	region := trace.StartRegion(ctx, name)
+	l.logEventAttr(ctx, log.SeverityDebug, name+".start", nil)
+	return func() {
+		region.End()
+		l.logEventAttr(ctx, log.SeverityDebug, name+".stop", []log.KeyValue{
+			log.Float64("duration_ms", float64(time.Since(start))/float64(time.Millisecond)),
+		})
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"bytes"
+	"runtime/trace"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+)
+
+func TestLogger_TraceRegion(t *testing.T) {
+	if trace.IsEnabled() {
+		t.Skip("execution tracing is already enabled")
+	}
+	var buf bytes.Buffer
+	require.NoError(t, trace.Start(&buf))
+	defer trace.Stop()
+
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"})
+
+	ctx := t.Context()
+	stop := logger.TraceRegion(ctx, "olog.test.region")
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	require.Len(t, records, 1, "stop has not been called")
+	stop()
+	trace.Stop()
+
+	assert.True(t, bytes.Contains(buf.Bytes(), []byte("olog.test.region")), "region not found in the execution trace")
+
+	records = recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	require.Len(t, records, 2)
+	assert.Equal(t, "olog.test.region.start", records[0].EventName)
+	assert.Equal(t, log.SeverityDebug, records[0].Severity)
+	assert.Equal(t, "olog.test.region.stop", records[1].EventName)
+	assert.Equal(t, log.SeverityDebug, records[1].Severity)
+	require.Len(t, records[1].Attributes, 1)
+	assert.Equal(t, "duration_ms", records[1].Attributes[0].Key)
+}
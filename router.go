@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import (
+	"context"
+	"errors"
+	"maps"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/embedded"
+)
+
+// NewRouter returns a Logger that emits each log record through the logger
+// of routes mapped to its exact severity, or through fallback if there is none.
+// Records without a route are dropped if fallback is nil.
+// The routed logger applies its options to the record, but not its attributes;
+// the attributes added to the returned logger with With and WithAttr are kept.
+// Enabled reports whether the route of the severity is enabled.
+//
+//	logger := olog.NewRouter(map[log.Severity]*olog.Logger{
+//		log.SeverityError: errorsLogger,
+//	}, defaultLogger)
+func NewRouter(routes map[log.Severity]*Logger, fallback *Logger) *Logger {
+	provider := routerProvider{routes: maps.Clone(routes), fallback: fallback}
+	return New(Options{Provider: provider, Name: "github.com/pellared/olog/router"})
+}
+
+// routerProvider is a LoggerProvider whose loggers route the records by severity.
+type routerProvider struct {
+	embedded.LoggerProvider
+
+	routes   map[log.Severity]*Logger
+	fallback *Logger
+}
+
+// Logger returns a logger routing the records by severity.
+func (p routerProvider) Logger(string, ...log.LoggerOption) log.Logger {
+	return routerLogger{routes: p.routes, fallback: p.fallback}
+}
+
+// ForceFlush flushes the loggers of all routes.
+func (p routerProvider) ForceFlush(ctx context.Context) error {
+	var errs []error
+	for _, l := range p.routes {
+		errs = append(errs, l.Flush(ctx))
+	}
+	if p.fallback != nil {
+		errs = append(errs, p.fallback.Flush(ctx))
+	}
+	return errors.Join(errs...)
+}
+
+// routerLogger is a Logger routing the records by severity.
+type routerLogger struct {
+	embedded.Logger
+
+	routes   map[log.Severity]*Logger
+	fallback *Logger
+}
+
+// route returns the logger of the severity, or nil if there is none.
+func (l routerLogger) route(level log.Severity) *Logger {
+	if target, ok := l.routes[level]; ok && target != nil {
+		return target
+	}
+	return l.fallback
+}
+
+// Emit emits the record through the logger of its severity.
+func (l routerLogger) Emit(ctx context.Context, record log.Record) {
+	if target := l.route(record.Severity()); target != nil {
+		target.emit(ctx, record)
+	}
+}
+
+// Enabled reports whether the logger of the severity is enabled.
+func (l routerLogger) Enabled(ctx context.Context, param log.EnabledParameters) bool {
+	target := l.route(param.Severity)
+	return target != nil && target.Enabled(ctx, param)
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+)
+
+func TestNewRouter(t *testing.T) {
+	errorRecorder := logtest.NewRecorder()
+	infoRecorder := logtest.NewRecorder()
+	errorLogger := New(Options{Provider: errorRecorder, Name: "errors"})
+	infoLogger := New(Options{Provider: infoRecorder, Name: "default"})
+
+	router := NewRouter(map[log.Severity]*Logger{log.SeverityError: errorLogger}, infoLogger).With("service", "api")
+
+	ctx := t.Context()
+	router.Error(ctx, "failed", "id", 1)
+	router.Info(ctx, "started")
+	router.WarnEvent(ctx, "slow")
+
+	errorRecords := errorRecorder.Result()[logtest.Scope{Name: "errors"}]
+	require.Len(t, errorRecords, 1)
+	assert.Equal(t, log.StringValue("failed"), errorRecords[0].Body)
+	assert.Equal(t, []log.KeyValue{log.String("service", "api"), log.Int64("id", 1)}, errorRecords[0].Attributes)
+
+	infoRecords := infoRecorder.Result()[logtest.Scope{Name: "default"}]
+	require.Len(t, infoRecords, 2)
+	assert.Equal(t, log.StringValue("started"), infoRecords[0].Body)
+	assert.Equal(t, []log.KeyValue{log.String("service", "api")}, infoRecords[0].Attributes)
+	assert.Equal(t, "slow", infoRecords[1].EventName)
+}
+
+func TestNewRouter_Enabled(t *testing.T) {
+	disabled := New(Options{
+		Provider: logtest.NewRecorder(logtest.WithEnabledFunc(func(context.Context, log.EnabledParameters) bool {
+			return false
+		})),
+		Name: "disabled",
+	})
+	enabled := New(Options{Provider: logtest.NewRecorder(), Name: "enabled"})
+
+	router := NewRouter(map[log.Severity]*Logger{log.SeverityDebug: disabled}, enabled)
+	noFallback := NewRouter(map[log.Severity]*Logger{log.SeverityError: enabled}, nil)
+
+	ctx := t.Context()
+	assert.False(t, router.DebugEnabled(ctx))
+	assert.True(t, router.InfoEnabled(ctx))
+	assert.True(t, noFallback.ErrorEnabled(ctx))
+	assert.False(t, noFallback.InfoEnabled(ctx))
+
+	// Records without a route and fallback are dropped.
+	noFallback.Info(ctx, "dropped")
+}
+
+func TestNewRouter_Flush(t *testing.T) {
+	errorProvider := &flushRecorder{Recorder: logtest.NewRecorder(), err: errors.New("flush failed")}
+	fallbackProvider := &flushRecorder{Recorder: logtest.NewRecorder()}
+	router := NewRouter(
+		map[log.Severity]*Logger{log.SeverityError: New(Options{Provider: errorProvider, Name: "errors"})},
+		New(Options{Provider: fallbackProvider, Name: "default"}),
+	)
+
+	err := router.Flush(t.Context())
+	assert.ErrorIs(t, err, errorProvider.err)
+	assert.Equal(t, 1, errorProvider.flushes)
+	assert.Equal(t, 1, fallbackProvider.flushes)
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// randFloat64 returns a pseudo-random number in [0.0,1.0).
+// The top-level math/rand/v2 functions use a per-thread generator,
+// so it is safe and cheap to call concurrently. It is a variable for testing.
+var randFloat64 = rand.Float64
+
+// TraceSampled logs a trace message with optional key-value pairs
+// with the probability ratio.
+func (l *Logger) TraceSampled(ctx context.Context, ratio float64, msg string, args ...any) {
+	l.logSampled(ctx, ratio, log.SeverityTrace, msg, args)
+}
+
+// DebugSampled logs a debug message with optional key-value pairs
+// with the probability ratio.
+func (l *Logger) DebugSampled(ctx context.Context, ratio float64, msg string, args ...any) {
+	l.logSampled(ctx, ratio, log.SeverityDebug, msg, args)
+}
+
+// InfoSampled logs an info message with optional key-value pairs
+// with the probability ratio.
+func (l *Logger) InfoSampled(ctx context.Context, ratio float64, msg string, args ...any) {
+	l.logSampled(ctx, ratio, log.SeverityInfo, msg, args)
+}
+
+// WarnSampled logs a warning message with optional key-value pairs
+// with the probability ratio.
+func (l *Logger) WarnSampled(ctx context.Context, ratio float64, msg string, args ...any) {
+	l.logSampled(ctx, ratio, log.SeverityWarn, msg, args)
+}
+
+// ErrorSampled logs an error message with optional key-value pairs
+// with the probability ratio.
+func (l *Logger) ErrorSampled(ctx context.Context, ratio float64, msg string, args ...any) {
+	l.logSampled(ctx, ratio, log.SeverityError, msg, args)
+}
+
+// logSampled logs a message with the probability ratio. The records dropped
+// by the ratio are passed to Options.OnDrop with the "sampled" reason,
+// or "min_severity" if they are below the minimum severity as well.
+func (l *Logger) logSampled(ctx context.Context, ratio float64, level log.Severity, msg string, args []any) {
+	if sampled(ratio) {
+		l.log(ctx, level, msg, args)
+		return
+	}
+	if l.cfg.onDrop == nil {
+		return
+	}
+	var record log.Record
+	record.SetBody(l.body(ctx, msg))
+	record.SetTimestamp(l.now())
+	record.SetSeverity(level)
+	l.addAttributes(ctx, &record, args)
+
+	reason := l.dropReason(ctx, l.cfg.filters.Load(), level, "", false)
+	if reason == "" {
+		reason = "sampled"
+	}
+	l.cfg.onDrop(ctx, record, reason)
+}
+
+// sampled reports whether a call with the given ratio should be emitted.
+// A ratio of 1 or more always emits, a ratio of 0 or less never emits.
+func sampled(ratio float64) bool {
+	if ratio >= 1 {
+		return true
+	}
+	if ratio <= 0 {
+		return false
+	}
+	return randFloat64() < ratio
+}
+
+// DeadlineSampler returns a Sampler that sheds load when the logging context is
+// close to its deadline: it drops the log records with a severity below info
+// if less than minRemaining is left until the deadline of the context.
+// Records of info severity and above, and records logged with a context
+// without a deadline, are kept.
+//
+//	logger := olog.New(olog.Options{Sampler: olog.DeadlineSampler(50 * time.Millisecond)})
+func DeadlineSampler(minRemaining time.Duration) Sampler {
+	return func(ctx context.Context, level log.Severity, _ string) bool {
+		if level >= log.SeverityInfo1 {
+			return true
+		}
+		deadline, ok := ctx.Deadline()
+		return !ok || time.Until(deadline) >= minRemaining
+	}
+}
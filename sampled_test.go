@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"context"
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+)
+
+func TestLogger_Sampled(t *testing.T) {
+	orig := randFloat64
+	randFloat64 = rand.New(rand.NewPCG(1, 2)).Float64 //nolint:gosec // Deterministic test RNG.
+	t.Cleanup(func() { randFloat64 = orig })
+
+	const calls = 1000
+
+	tests := []struct {
+		name    string
+		ratio   float64
+		wantMin int
+		wantMax int
+	}{
+		{name: "never", ratio: 0, wantMin: 0, wantMax: 0},
+		{name: "always", ratio: 1, wantMin: calls, wantMax: calls},
+		{name: "half", ratio: 0.5, wantMin: 450, wantMax: 550},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := logtest.NewRecorder()
+			logger := New(Options{Provider: recorder, Name: "test-logger"})
+
+			ctx := t.Context()
+			for range calls {
+				logger.InfoSampled(ctx, tt.ratio, "sampled message", "key", "value")
+			}
+
+			got := len(recorder.Result()[logtest.Scope{Name: "test-logger"}])
+			if got < tt.wantMin || got > tt.wantMax {
+				t.Errorf("got %d records, want between %d and %d", got, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestLogger_SampledLevels(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"})
+
+	ctx := t.Context()
+	logger.TraceSampled(ctx, 1, "trace")
+	logger.DebugSampled(ctx, 1, "debug")
+	logger.InfoSampled(ctx, 1, "info")
+	logger.WarnSampled(ctx, 1, "warn")
+	logger.ErrorSampled(ctx, 1, "error")
+	logger.ErrorSampled(ctx, -1, "dropped")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	if len(records) != 5 {
+		t.Fatalf("expected 5 records, got %d", len(records))
+	}
+	for i, body := range []string{"trace", "debug", "info", "warn", "error"} {
+		if got := records[i].Body.AsString(); got != body {
+			t.Errorf("record %d: got body %q, want %q", i, got, body)
+		}
+	}
+}
+
+func TestLogger_SampledOnDrop(t *testing.T) {
+	type drop struct {
+		body   string
+		reason string
+	}
+	var drops []drop
+	recorder := logtest.NewRecorder()
+	logger := New(Options{
+		Provider:    recorder,
+		Name:        "test-logger",
+		MinSeverity: log.SeverityInfo,
+		OnDrop: func(_ context.Context, r log.Record, reason string) {
+			drops = append(drops, drop{body: r.Body().AsString(), reason: reason})
+		},
+	})
+
+	ctx := t.Context()
+	logger.InfoSampled(ctx, 0, "info")
+	logger.DebugSampled(ctx, 0, "debug")
+	logger.WarnSampled(ctx, 1, "warn")
+
+	want := []drop{
+		{body: "info", reason: "sampled"},
+		{body: "debug", reason: "min_severity"},
+	}
+	if !slices.Equal(drops, want) {
+		t.Errorf("got drops %v, want %v", drops, want)
+	}
+	if got := len(recorder.Result()[logtest.Scope{Name: "test-logger"}]); got != 1 {
+		t.Errorf("got %d records, want 1", got)
+	}
+}
+
+func TestDeadlineSampler(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     func(t *testing.T) context.Context
+		wantLen int
+	}{
+		{
+			name: "near deadline",
+			ctx: func(t *testing.T) context.Context {
+				ctx, cancel := context.WithTimeout(t.Context(), time.Millisecond)
+				t.Cleanup(cancel)
+				return ctx
+			},
+			// Only the info and error records are kept.
+			wantLen: 2,
+		},
+		{
+			name: "ample time",
+			ctx: func(t *testing.T) context.Context {
+				ctx, cancel := context.WithTimeout(t.Context(), time.Hour)
+				t.Cleanup(cancel)
+				return ctx
+			},
+			wantLen: 4,
+		},
+		{
+			name:    "no deadline",
+			ctx:     func(t *testing.T) context.Context { return t.Context() },
+			wantLen: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := logtest.NewRecorder()
+			logger := New(Options{Provider: recorder, Name: "test-logger", Sampler: DeadlineSampler(time.Minute)})
+
+			ctx := tt.ctx(t)
+			logger.Trace(ctx, "trace")
+			logger.Debug(ctx, "debug")
+			logger.Info(ctx, "info")
+			logger.Error(ctx, "error")
+
+			records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+			if len(records) != tt.wantLen {
+				t.Fatalf("got %d records, want %d", len(records), tt.wantLen)
+			}
+			if got := records[len(records)-1].Severity; got != log.SeverityError {
+				t.Errorf("got last severity %v, want %v", got, log.SeverityError)
+			}
+		})
+	}
+}
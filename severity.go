@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// severityBucketKey is the attribute key of Options.SeverityBucketAttr.
+const severityBucketKey = "severity.bucket"
+
+// SeverityBucket returns the lowercase name of the severity range of the
+// severity (trace, debug, info, warn, error, fatal), for example "warn"
+// for log.SeverityWarn3. It returns an empty string for an undefined severity.
+// It is the default Options.SeverityBucketFunc.
+func SeverityBucket(level log.Severity) string {
+	switch {
+	case level <= log.SeverityUndefined:
+		return ""
+	case level <= log.SeverityTrace4:
+		return "trace"
+	case level <= log.SeverityDebug4:
+		return "debug"
+	case level <= log.SeverityInfo4:
+		return "info"
+	case level <= log.SeverityWarn4:
+		return "warn"
+	case level <= log.SeverityError4:
+		return "error"
+	default:
+		return "fatal"
+	}
+}
+
+// SyslogSeverityText returns the syslog keyword of the severity
+// (emerg, alert, crit, err, warning, notice, info, debug).
+// It returns an empty string for an undefined severity.
+// It can be used as Options.SeverityTextFunc for syslog-based collectors.
+func SyslogSeverityText(level log.Severity) string {
+	switch {
+	case level <= log.SeverityUndefined:
+		return ""
+	case level <= log.SeverityDebug4:
+		return "debug"
+	case level == log.SeverityInfo1:
+		return "info"
+	case level <= log.SeverityInfo4:
+		return "notice"
+	case level <= log.SeverityWarn4:
+		return "warning"
+	case level <= log.SeverityError4:
+		return "err"
+	case level <= log.SeverityFatal2:
+		return "crit"
+	case level == log.SeverityFatal3:
+		return "alert"
+	default:
+		return "emerg"
+	}
+}
+
+// SeverityFromText returns the severity described by text, ignoring case
+// and surrounding whitespace. It is the reverse of log.Severity.String,
+// accepting the canonical OpenTelemetry names (TRACE, TRACE2, ..., FATAL4,
+// with an optional 1 suffix for the first severity of a range), and of
+// SyslogSeverityText, accepting the syslog keywords. It returns false
+// for unknown text.
+func SeverityFromText(text string) (log.Severity, bool) {
+	text = strings.ToUpper(strings.TrimSpace(text))
+	for level := log.SeverityTrace1; level <= log.SeverityFatal4; level++ {
+		name := level.String()
+		if text == name || ((level-log.SeverityTrace1)%4 == 0 && text == name+"1") {
+			return level, true
+		}
+	}
+	switch text {
+	case "EMERG", "EMERGENCY", "PANIC":
+		return log.SeverityFatal4, true
+	case "ALERT":
+		return log.SeverityFatal3, true
+	case "CRIT", "CRITICAL":
+		return log.SeverityFatal1, true
+	case "ERR":
+		return log.SeverityError1, true
+	case "WARNING":
+		return log.SeverityWarn1, true
+	case "NOTICE":
+		return log.SeverityInfo2, true
+	}
+	return log.SeverityUndefined, false
+}
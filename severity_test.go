@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+)
+
+func TestSyslogSeverityText(t *testing.T) {
+	for _, tt := range []struct {
+		level log.Severity
+		want  string
+	}{
+		{level: log.SeverityUndefined, want: ""},
+		{level: log.SeverityTrace, want: "debug"},
+		{level: log.SeverityDebug4, want: "debug"},
+		{level: log.SeverityInfo, want: "info"},
+		{level: log.SeverityInfo2, want: "notice"},
+		{level: log.SeverityWarn, want: "warning"},
+		{level: log.SeverityError3, want: "err"},
+		{level: log.SeverityFatal, want: "crit"},
+		{level: log.SeverityFatal3, want: "alert"},
+		{level: log.SeverityFatal4, want: "emerg"},
+	} {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			if got := SyslogSeverityText(tt.level); got != tt.want {
+				t.Errorf("SyslogSeverityText(%v) = %q, want %q", tt.level, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew_SeverityTextFunc(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger", SeverityTextFunc: SyslogSeverityText})
+
+	ctx := t.Context()
+	logger.Info(ctx, "info")
+	logger.Warn(ctx, "warn")
+	logger.ErrorEvent(ctx, "failure")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	want := []string{"info", "warning", "err"}
+	if len(records) != len(want) {
+		t.Fatalf("expected %d records, got %d", len(want), len(records))
+	}
+	for i, r := range records {
+		if r.SeverityText != want[i] {
+			t.Errorf("record %d: got severity text %q, want %q", i, r.SeverityText, want[i])
+		}
+	}
+}
+
+func TestSeverityFromText(t *testing.T) {
+	for _, tt := range []struct {
+		text   string
+		want   log.Severity
+		wantOK bool
+	}{
+		{text: "TRACE", want: log.SeverityTrace1, wantOK: true},
+		{text: "trace1", want: log.SeverityTrace1, wantOK: true},
+		{text: "Debug3", want: log.SeverityDebug3, wantOK: true},
+		{text: " INFO ", want: log.SeverityInfo1, wantOK: true},
+		{text: "WARN4", want: log.SeverityWarn4, wantOK: true},
+		{text: "error", want: log.SeverityError1, wantOK: true},
+		{text: "FATAL2", want: log.SeverityFatal2, wantOK: true},
+		{text: "emerg", want: log.SeverityFatal4, wantOK: true},
+		{text: "alert", want: log.SeverityFatal3, wantOK: true},
+		{text: "crit", want: log.SeverityFatal1, wantOK: true},
+		{text: "err", want: log.SeverityError1, wantOK: true},
+		{text: "warning", want: log.SeverityWarn1, wantOK: true},
+		{text: "notice", want: log.SeverityInfo2, wantOK: true},
+		{text: "", want: log.SeverityUndefined, wantOK: false},
+		{text: "UNDEFINED", want: log.SeverityUndefined, wantOK: false},
+		{text: "INFO5", want: log.SeverityUndefined, wantOK: false},
+		{text: "WARN21", want: log.SeverityUndefined, wantOK: false},
+		{text: "verbose", want: log.SeverityUndefined, wantOK: false},
+	} {
+		t.Run(tt.text, func(t *testing.T) {
+			got, ok := SeverityFromText(tt.text)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("SeverityFromText(%q) = %v, %v, want %v, %v", tt.text, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestSeverityFromTextRoundTrip(t *testing.T) {
+	for _, fn := range []struct {
+		name string
+		text func(log.Severity) string
+	}{
+		{name: "String", text: log.Severity.String},
+		{name: "SyslogSeverityText", text: SyslogSeverityText},
+	} {
+		t.Run(fn.name, func(t *testing.T) {
+			recorder := logtest.NewRecorder()
+			logger := New(Options{Provider: recorder, Name: "test-logger", SeverityTextFunc: fn.text})
+			for level := log.SeverityTrace1; level <= log.SeverityFatal4; level++ {
+				logger.Log(t.Context(), level, "msg")
+			}
+
+			for _, r := range recorder.Result()[logtest.Scope{Name: "test-logger"}] {
+				got, ok := SeverityFromText(r.SeverityText)
+				if !ok {
+					t.Errorf("SeverityFromText(%q) failed", r.SeverityText)
+					continue
+				}
+				if text := fn.text(got); text != r.SeverityText {
+					t.Errorf("severity %v: round trip of %q gives %q", r.Severity, r.SeverityText, text)
+				}
+			}
+		})
+	}
+}
+
+func TestSeverityBucket(t *testing.T) {
+	for _, tt := range []struct {
+		level log.Severity
+		want  string
+	}{
+		{level: log.SeverityUndefined, want: ""},
+		{level: log.SeverityTrace1, want: "trace"},
+		{level: log.SeverityTrace4, want: "trace"},
+		{level: log.SeverityDebug2, want: "debug"},
+		{level: log.SeverityInfo, want: "info"},
+		{level: log.SeverityWarn3, want: "warn"},
+		{level: log.SeverityError4, want: "error"},
+		{level: log.SeverityFatal4, want: "fatal"},
+	} {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			if got := SeverityBucket(tt.level); got != tt.want {
+				t.Errorf("SeverityBucket(%v) = %q, want %q", tt.level, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew_SeverityBucketAttr(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		fn   func(log.Severity) string
+		want string
+	}{
+		{name: "default", fn: nil, want: "warn"},
+		{name: "custom", fn: func(level log.Severity) string {
+			if level >= log.SeverityWarn {
+				return "high"
+			}
+			return "low"
+		}, want: "high"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := logtest.NewRecorder()
+			logger := New(Options{
+				Provider:           recorder,
+				Name:               "test-logger",
+				SeverityBucketAttr: true,
+				SeverityBucketFunc: tt.fn,
+			})
+
+			logger.Log(t.Context(), log.SeverityWarn3, "warn3", "n", 1)
+
+			records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+			if len(records) != 1 {
+				t.Fatalf("expected 1 record, got %d", len(records))
+			}
+			want := []log.KeyValue{log.Int64("n", 1), log.String("severity.bucket", tt.want)}
+			if !equalKeyValues(records[0].Attributes, want) {
+				t.Errorf("got attributes %v, want %v", records[0].Attributes, want)
+			}
+		})
+	}
+}
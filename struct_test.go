@@ -24,19 +24,19 @@ func TestLogger_StructBehavior(t *testing.T) {
 	}
 
 	// Original logger should not have attributes
-	if len(logger.attrs) != 0 {
-		t.Errorf("Original logger should have no attrs, got %d", len(logger.attrs))
+	if logger.attrs.Len() != 0 {
+		t.Errorf("Original logger should have no attrs, got %d", logger.attrs.Len())
 	}
 
 	// With logger should have attributes
-	if len(withLogger.attrs) != 1 {
-		t.Errorf("With logger should have 1 KeyValue attr, got %d", len(withLogger.attrs))
+	if withLogger.attrs.Len() != 1 {
+		t.Errorf("With logger should have 1 KeyValue attr, got %d", withLogger.attrs.Len())
 	}
 
 	// Test chaining With calls
 	chainedLogger := withLogger.With("key2", "value2")
-	if len(chainedLogger.attrs) != 2 {
-		t.Errorf("Chained logger should have 2 KeyValue attrs, got %d", len(chainedLogger.attrs))
+	if chainedLogger.attrs.Len() != 2 {
+		t.Errorf("Chained logger should have 2 KeyValue attrs, got %d", chainedLogger.attrs.Len())
 	}
 
 	// Test logging doesn't panic
@@ -50,24 +50,25 @@ func TestLogger_AttributeHandling(t *testing.T) {
 
 	// Test that attributes are properly stored
 	withLogger := logger.With("service", "api", "version", "1.0")
-	if len(withLogger.attrs) != 2 {
-		t.Errorf("Expected 2 KeyValue attrs, got %d", len(withLogger.attrs))
+	if withLogger.attrs.Len() != 2 {
+		t.Errorf("Expected 2 KeyValue attrs, got %d", withLogger.attrs.Len())
 	}
 
 	// Check the key-value pairs
 	expectedKeys := []string{"service", "version"}
 	expectedValues := []string{"api", "1.0"}
 
-	if len(withLogger.attrs) != len(expectedKeys) {
-		t.Fatalf("Attr length mismatch: expected %d, got %d", len(expectedKeys), len(withLogger.attrs))
+	if withLogger.attrs.Len() != len(expectedKeys) {
+		t.Fatalf("Attr length mismatch: expected %d, got %d", len(expectedKeys), withLogger.attrs.Len())
 	}
 
+	attrs := withLogger.attrs.All()
 	for i, expectedKey := range expectedKeys {
-		if withLogger.attrs[i].Key != expectedKey {
-			t.Errorf("Attr[%d] key: expected %s, got %s", i, expectedKey, withLogger.attrs[i].Key)
+		if attrs[i].Key != expectedKey {
+			t.Errorf("Attr[%d] key: expected %s, got %s", i, expectedKey, attrs[i].Key)
 		}
-		if withLogger.attrs[i].Value.AsString() != expectedValues[i] {
-			t.Errorf("Attr[%d] value: expected %s, got %s", i, expectedValues[i], withLogger.attrs[i].Value.AsString())
+		if attrs[i].Value.AsString() != expectedValues[i] {
+			t.Errorf("Attr[%d] value: expected %s, got %s", i, expectedValues[i], attrs[i].Value.AsString())
 		}
 	}
 }
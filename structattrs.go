@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import (
+	"reflect"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// StructAttrs returns the exported fields of the struct v as attributes.
+// v may also be a pointer to a struct. For other values, nil is returned.
+//
+// The attribute key is the field name, unless it is overridden with an olog
+// struct tag. Fields tagged with olog:"-" are skipped.
+// Field values are converted the same way as values of key-value arguments.
+//
+//	type Request struct {
+//		Method string `olog:"http.method"`
+//		Path   string
+//		Token  string `olog:"-"`
+//	}
+func StructAttrs(v any) []log.KeyValue {
+	return converter{}.structAttrs(v)
+}
+
+// WithFields returns a new Logger that includes the exported fields of the
+// struct fields, or of the struct it points to, as attributes in all log records.
+// The attributes are the ones returned by StructAttrs:
+//
+//	requestLogger := logger.WithFields(reqMeta)
+func (l *Logger) WithFields(fields any) *Logger {
+	if l.IsNoop() {
+		return nopLogger
+	}
+	attrs := l.conv.structAttrs(fields)
+	c := l.clone()
+	c.attrs = newAttrNode(l.attrs, l.appendNamespaced(make([]log.KeyValue, 0, len(attrs)), attrs))
+	return c
+}
+
+// structAttrs returns the exported fields of the struct v as attributes, see StructAttrs.
+func (c converter) structAttrs(v any) []log.KeyValue {
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return nil
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return nil
+	}
+
+	t := val.Type()
+	attrs := make([]log.KeyValue, 0, t.NumField())
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		key := field.Name
+		if tag, ok := field.Tag.Lookup("olog"); ok {
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				key = tag
+			}
+		}
+		attrs = append(attrs, log.KeyValue{
+			Key:   key,
+			Value: c.convert(val.Field(i).Interface()),
+		})
+	}
+	return attrs
+}
+
+// isStruct reports whether v is a struct or a non-nil pointer to a struct.
+func isStruct(v any) bool {
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return false
+		}
+		val = val.Elem()
+	}
+	return val.Kind() == reflect.Struct
+}
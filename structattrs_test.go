@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+)
+
+type testRequest struct {
+	Method string `olog:"http.method"`
+	Path   string
+	Token  string `olog:"-"`
+	Status int
+	secret string
+}
+
+func TestStructAttrs(t *testing.T) {
+	req := testRequest{Method: "GET", Path: "/users", Token: "t0k3n", Status: 200, secret: "hidden"}
+	want := []log.KeyValue{
+		log.String("http.method", "GET"),
+		log.String("Path", "/users"),
+		log.Int64("Status", 200),
+	}
+
+	assert.Equal(t, want, StructAttrs(req))
+	assert.Equal(t, want, StructAttrs(&req))
+	assert.Nil(t, StructAttrs((*testRequest)(nil)))
+	assert.Nil(t, StructAttrs("not a struct"))
+}
+
+func TestLogger_FlattenStructArgs(t *testing.T) {
+	req := testRequest{Method: "POST", Path: "/orders", Status: 201}
+
+	tests := []struct {
+		name    string
+		flatten bool
+		want    []log.KeyValue
+	}{
+		{
+			name:    "flatten",
+			flatten: true,
+			want: []log.KeyValue{
+				log.String("http.method", "POST"),
+				log.String("Path", "/orders"),
+				log.Int64("Status", 201),
+				log.String("key", "value"),
+			},
+		},
+		{
+			name:    "default",
+			flatten: false,
+			// The struct is treated as an invalid key, skipping its pair.
+			want: []log.KeyValue{
+				log.String("value", ""),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := logtest.NewRecorder()
+			logger := New(Options{Provider: recorder, Name: "test-logger", FlattenStructArgs: tt.flatten})
+
+			logger.Info(t.Context(), "state", req, "key", "value")
+
+			records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+			if len(records) != 1 {
+				t.Fatalf("expected 1 record, got %d", len(records))
+			}
+			assert.Equal(t, tt.want, records[0].Attributes)
+		})
+	}
+}
+
+func TestLogger_WithFields(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger"})
+	req := &testRequest{Method: "GET", Path: "/users", Token: "t0k3n", Status: 200, secret: "hidden"}
+
+	ctx := t.Context()
+	requestLogger := logger.WithFields(req)
+	requestLogger.Info(ctx, "handled", "duration", 5)
+	logger.WithNamespace("req").WithFields(req).Info(ctx, "namespaced")
+	logger.WithFields("not a struct").Info(ctx, "ignored")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	if len(records) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(records))
+	}
+	assert.Equal(t, []log.KeyValue{
+		log.String("http.method", "GET"),
+		log.String("Path", "/users"),
+		log.Int64("Status", 200),
+		log.Int64("duration", 5),
+	}, records[0].Attributes)
+	assert.Equal(t, []log.KeyValue{
+		log.String("req.http.method", "GET"),
+		log.String("req.Path", "/users"),
+		log.Int64("req.Status", 200),
+	}, records[1].Attributes)
+	assert.Empty(t, records[2].Attributes)
+}
@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// repeatedKey is the attribute key of the number of log records suppressed by a Throttle.
+const repeatedKey = "repeated"
+
+// Throttle is a middleware that coalesces consecutive identical log records
+// into a summary. See NewThrottle.
+type Throttle struct {
+	window time.Duration
+	now    func() time.Time
+
+	stopTicker func()
+	done       chan struct{}
+	stopped    chan struct{}
+	closeOnce  sync.Once
+
+	mu     sync.Mutex
+	closed bool
+	// last is the last emitted record and chain is the middleware chain it was passed to.
+	last  log.Record
+	chain *emitChain
+	start time.Time
+	// repeated is the number of suppressed duplicates of last.
+	repeated int64
+	// dup is the last suppressed duplicate.
+	dup    log.Record
+	dupCtx context.Context
+}
+
+// throttleSummary is a summary of suppressed duplicates to be emitted.
+type throttleSummary struct {
+	ctx    context.Context
+	record log.Record
+	next   EmitFunc
+}
+
+// NewThrottle returns a Throttle coalescing consecutive identical log records,
+// with the same severity, body, and event name, emitted by the same logger.
+// The first record is emitted and the duplicates within window from it are suppressed.
+// When a differing record or a duplicate after the window is emitted, or the
+// window expires, a summary, the last suppressed record with the repeated attribute
+// set to the number of suppressed records, is emitted. Expired windows are
+// summarized every window by a background goroutine, which is stopped by Close.
+//
+//	throttle := olog.NewThrottle(time.Second)
+//	defer throttle.Close()
+//	logger := olog.New(olog.Options{Middleware: []olog.Middleware{throttle.Middleware}})
+//
+// The throttle is safe for concurrent use and can be shared by loggers.
+func NewThrottle(window time.Duration) *Throttle {
+	return newThrottle(window, systemTicker)
+}
+
+// newThrottle returns a Throttle using tk, see NewThrottle.
+func newThrottle(window time.Duration, tk ticker) *Throttle {
+	ticks, stop := tk.start(window)
+	t := &Throttle{
+		window:     window,
+		now:        tk.now,
+		stopTicker: stop,
+		done:       make(chan struct{}),
+		stopped:    make(chan struct{}),
+	}
+	go t.run(ticks)
+	return t
+}
+
+// Middleware is the Middleware throttling the log records passed to next.
+func (t *Throttle) Middleware(next EmitFunc) EmitFunc {
+	chain := &emitChain{next: next}
+	return func(ctx context.Context, r log.Record) {
+		t.emit(ctx, r, chain)
+	}
+}
+
+// Close emits the summary of the suppressed log records and stops the
+// background goroutine. The records emitted afterwards are passed through
+// without throttling. It always returns nil.
+func (t *Throttle) Close() error {
+	t.closeOnce.Do(func() {
+		t.stopTicker()
+		close(t.done)
+		<-t.stopped
+
+		t.mu.Lock()
+		t.closed = true
+		s, ok := t.summary()
+		t.mu.Unlock()
+		if ok {
+			s.emit()
+		}
+	})
+	return nil
+}
+
+func (t *Throttle) emit(ctx context.Context, r log.Record, chain *emitChain) {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		chain.next(ctx, r)
+		return
+	}
+
+	now := t.now()
+	if chain == t.chain && sameRecord(t.last, r) && now.Sub(t.start) < t.window {
+		t.repeated++
+		// The record may be emitted after the call returns.
+		t.dup, t.dupCtx = r.Clone(), context.WithoutCancel(ctx)
+		t.mu.Unlock()
+		return
+	}
+	s, ok := t.summary()
+	t.last, t.chain, t.start = r.Clone(), chain, now
+	t.mu.Unlock()
+
+	// The records are emitted without holding the lock
+	// as the exporters may be slow.
+	if ok {
+		s.emit()
+	}
+	chain.next(ctx, r)
+}
+
+// run summarizes the expired windows on every tick until Close is called.
+func (t *Throttle) run(ticks <-chan time.Time) {
+	defer close(t.stopped)
+	for {
+		select {
+		case <-t.done:
+			return
+		case now := <-ticks:
+			t.mu.Lock()
+			var (
+				s  throttleSummary
+				ok bool
+			)
+			if now.Sub(t.start) >= t.window {
+				s, ok = t.summary()
+			}
+			t.mu.Unlock()
+			if ok {
+				s.emit()
+			}
+		}
+	}
+}
+
+// summary returns and resets the summary of the suppressed duplicates,
+// and whether there are any. t.mu must be held.
+func (t *Throttle) summary() (throttleSummary, bool) {
+	if t.repeated == 0 {
+		return throttleSummary{}, false
+	}
+	s := throttleSummary{ctx: t.dupCtx, record: t.dup, next: t.chain.next}
+	s.record.AddAttributes(log.Int64(repeatedKey, t.repeated))
+	t.repeated, t.dup, t.dupCtx = 0, log.Record{}, nil
+	return s, true
+}
+
+func (s throttleSummary) emit() {
+	s.next(s.ctx, s.record)
+}
+
+// sameRecord reports whether a and b have the same severity, body, and event name.
+func sameRecord(a, b log.Record) bool {
+	return a.Severity() == b.Severity() && a.EventName() == b.EventName() && a.Body().Equal(b.Body())
+}
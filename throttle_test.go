@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+)
+
+// fakeClock is a manually advanced clock.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+// newTicker returns a ticker reading c
+// and ticking when a time is sent on the returned channel.
+func (c *fakeClock) newTicker() (ticker, chan<- time.Time) {
+	ticks := make(chan time.Time)
+	return ticker{
+		now:   c.Now,
+		start: func(time.Duration) (<-chan time.Time, func()) { return ticks, func() {} },
+	}, ticks
+}
+
+// newTestThrottle returns a throttle using tk closed when the test ends.
+func newTestThrottle(t *testing.T, window time.Duration, tk ticker) *Throttle {
+	th := newThrottle(window, tk)
+	t.Cleanup(func() { _ = th.Close() })
+	return th
+}
+
+func TestNewThrottle(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)}
+	tk, _ := clock.newTicker()
+
+	recorder := logtest.NewRecorder()
+	logger := New(Options{
+		Provider:   recorder,
+		Name:       "test-logger",
+		Middleware: []Middleware{newTestThrottle(t, time.Second, tk).Middleware},
+	})
+
+	ctx := t.Context()
+	for i := range 4 {
+		logger.Warn(ctx, "disk full", "attempt", i)
+		clock.Advance(100 * time.Millisecond)
+	}
+	logger.Info(ctx, "different")
+	logger.Info(ctx, "different")
+	clock.Advance(time.Second)
+	logger.Info(ctx, "different")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	require.Len(t, records, 5)
+
+	assert.Equal(t, log.StringValue("disk full"), records[0].Body)
+	assert.Equal(t, []log.KeyValue{log.Int64("attempt", 0)}, records[0].Attributes)
+
+	// The summary is the last suppressed record with the repeated count.
+	assert.Equal(t, log.StringValue("disk full"), records[1].Body)
+	assert.Equal(t, log.SeverityWarn, records[1].Severity)
+	assert.Equal(t, []log.KeyValue{log.Int64("attempt", 3), log.Int64("repeated", 3)}, records[1].Attributes)
+
+	assert.Equal(t, log.StringValue("different"), records[2].Body)
+	assert.Empty(t, records[2].Attributes)
+
+	// The window expired, the duplicate is summarized and emitted.
+	assert.Equal(t, []log.KeyValue{log.Int64("repeated", 1)}, records[3].Attributes)
+	assert.Equal(t, log.StringValue("different"), records[4].Body)
+	assert.Empty(t, records[4].Attributes)
+}
+
+func TestNewThrottle_DifferentSeverityOrEvent(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)}
+	tk, _ := clock.newTicker()
+
+	recorder := logtest.NewRecorder()
+	logger := New(Options{
+		Provider:   recorder,
+		Name:       "test-logger",
+		Middleware: []Middleware{newTestThrottle(t, time.Minute, tk).Middleware},
+	})
+
+	ctx := t.Context()
+	logger.Info(ctx, "msg")
+	logger.Warn(ctx, "msg")
+	logger.InfoEvent(ctx, "event")
+	logger.InfoEvent(ctx, "other")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	assert.Len(t, records, 4)
+}
+
+func TestThrottle_WindowExpiry(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)}
+	tk, ticks := clock.newTicker()
+
+	recorder := logtest.NewRecorder()
+	logger := New(Options{
+		Provider:   recorder,
+		Name:       "test-logger",
+		Middleware: []Middleware{newTestThrottle(t, time.Second, tk).Middleware},
+	})
+
+	ctx := t.Context()
+	for i := range 3 {
+		logger.Warn(ctx, "disk full", "attempt", i)
+	}
+
+	// The window has not expired.
+	ticks <- clock.Now()
+	clock.Advance(time.Second)
+	// The trailing duplicates are summarized without another record.
+	ticks <- clock.Now()
+	// The second tick is received after the first is handled.
+	ticks <- clock.Now()
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	require.Len(t, records, 2)
+	assert.Equal(t, []log.KeyValue{log.Int64("attempt", 0)}, records[0].Attributes)
+	assert.Equal(t, []log.KeyValue{log.Int64("attempt", 2), log.Int64("repeated", 2)}, records[1].Attributes)
+}
+
+func TestThrottle_Close(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)}
+	tk, _ := clock.newTicker()
+
+	recorder := logtest.NewRecorder()
+	th := newThrottle(time.Minute, tk)
+	logger := New(Options{Provider: recorder, Name: "test-logger", Middleware: []Middleware{th.Middleware}})
+
+	ctx := t.Context()
+	logger.Info(ctx, "msg")
+	logger.Info(ctx, "msg")
+	require.NoError(t, th.Close())
+	logger.Info(ctx, "msg")
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	require.Len(t, records, 3)
+	assert.Empty(t, records[0].Attributes)
+	assert.Equal(t, []log.KeyValue{log.Int64("repeated", 1)}, records[1].Attributes, "Close emits the summary")
+	assert.Empty(t, records[2].Attributes, "records after Close are passed through")
+}
+
+func TestThrottle_Loggers(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)}
+	tk, _ := clock.newTicker()
+
+	recorder := logtest.NewRecorder()
+	th := newTestThrottle(t, time.Minute, tk)
+	a := New(Options{Provider: recorder, Name: "a", Middleware: []Middleware{th.Middleware}})
+	b := New(Options{Provider: recorder, Name: "b", Middleware: []Middleware{th.Middleware}})
+
+	ctx := t.Context()
+	a.Info(ctx, "msg")
+	b.Info(ctx, "msg")
+	a.Info(ctx, "msg")
+	a.Info(ctx, "msg")
+	b.Info(ctx, "other")
+
+	result := recorder.Result()
+	recordsA := result[logtest.Scope{Name: "a"}]
+	recordsB := result[logtest.Scope{Name: "b"}]
+	require.Len(t, recordsA, 3, "identical records of different loggers are not coalesced")
+	assert.Equal(t, []log.KeyValue{log.Int64("repeated", 1)}, recordsA[2].Attributes, "the summary is emitted by the logger of the records")
+	require.Len(t, recordsB, 2)
+	assert.Empty(t, recordsB[0].Attributes)
+	assert.Equal(t, log.StringValue("other"), recordsB[1].Body)
+}
@@ -0,0 +1,182 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import (
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// maskReplacement replaces the substrings matching Options.MaskPatterns.
+const maskReplacement = "***"
+
+// truncatedKey is the key of the attribute added to the log records
+// whose attributes exceed Options.MaxRecordBytes.
+const truncatedKey = "log.truncated"
+
+// transforms reports whether the configuration and f require the records to be transformed.
+func (c *config) transforms(f *filters) bool {
+	return len(f.maskPatterns) > 0 || c.omitEmpty || c.maxBytes > 0 ||
+		c.validateAttr != nil || c.attrPrefix != "" || len(c.keyAliases) > 0 ||
+		len(c.valueFuncs) > 0
+}
+
+// transformRecord returns the record with the configured transformations and f applied
+// to its body and attributes.
+func (l *Logger) transformRecord(f *filters, record log.Record) log.Record {
+	if !l.cfg.transforms(f) {
+		return record
+	}
+
+	var out log.Record
+	out.SetEventName(record.EventName())
+	out.SetTimestamp(record.Timestamp())
+	out.SetObservedTimestamp(record.ObservedTimestamp())
+	out.SetSeverity(record.Severity())
+	out.SetSeverityText(record.SeverityText())
+	body := f.maskValue(record.Body())
+	out.SetBody(body)
+
+	// The body counts toward Options.MaxRecordBytes first.
+	size := valueSize(body)
+	var truncated bool
+	record.WalkAttributes(func(kv log.KeyValue) bool {
+		kv, ok := l.transformAttr(f, kv)
+		if !ok {
+			return true
+		}
+		if l.cfg.maxBytes > 0 {
+			size += len(kv.Key) + valueSize(kv.Value)
+			if size > l.cfg.maxBytes {
+				truncated = true
+				return false
+			}
+		}
+		out.AddAttributes(kv)
+		return true
+	})
+	if truncated {
+		out.AddAttributes(log.Bool(truncatedKey, true))
+	}
+	return out
+}
+
+// transformAttr returns the attribute with the configured transformations and f applied.
+// It returns false if the attribute is dropped.
+func (l *Logger) transformAttr(f *filters, kv log.KeyValue) (log.KeyValue, bool) {
+	kv, ok := l.cfg.filterAttr(kv)
+	if !ok {
+		return kv, false
+	}
+	kv.Value = f.maskValue(kv.Value)
+	for _, fn := range l.cfg.valueFuncs {
+		if v, ok := fn(kv.Key, kv.Value); ok {
+			kv.Value = v
+			break
+		}
+	}
+	kv.Key = l.cfg.attrPrefix + kv.Key
+	return kv, true
+}
+
+// filterAttr returns the attribute with Options.KeyAliases applied.
+// It returns false if the attribute is dropped by Options.ValidateAttr
+// or Options.OmitEmpty.
+func (c *config) filterAttr(kv log.KeyValue) (log.KeyValue, bool) {
+	if alias, ok := c.keyAliases[kv.Key]; ok {
+		kv.Key = alias
+	}
+	if c.validateAttr != nil {
+		if err := c.validateAttr(kv); err != nil {
+			c.handleError(fmt.Errorf("olog: invalid attribute %q: %w", kv.Key, err))
+			return kv, false
+		}
+	}
+	if c.omitEmpty && isEmptyValue(kv.Value) {
+		return kv, false
+	}
+	return kv, true
+}
+
+// isEmptyValue reports whether v is empty, an empty string, or empty bytes.
+func isEmptyValue(v log.Value) bool {
+	switch v.Kind() {
+	case log.KindEmpty:
+		return true
+	case log.KindString:
+		return v.AsString() == ""
+	case log.KindBytes:
+		return len(v.AsBytes()) == 0
+	default:
+		return false
+	}
+}
+
+// valueSize returns the approximate serialized size of v in bytes:
+// the length of strings and bytes, 8 for numbers, 1 for booleans,
+// and the sum of the sizes of the items of slices and maps.
+func valueSize(v log.Value) int {
+	switch v.Kind() {
+	case log.KindString:
+		return len(v.AsString())
+	case log.KindBytes:
+		return len(v.AsBytes())
+	case log.KindInt64, log.KindFloat64:
+		return 8
+	case log.KindBool:
+		return 1
+	case log.KindSlice:
+		var n int
+		for _, item := range v.AsSlice() {
+			n += valueSize(item)
+		}
+		return n
+	case log.KindMap:
+		var n int
+		for _, kv := range v.AsMap() {
+			n += len(kv.Key) + valueSize(kv.Value)
+		}
+		return n
+	default:
+		return 0
+	}
+}
+
+// maskValue replaces the substrings of string values matching Options.MaskPatterns.
+// Slices and maps are masked recursively.
+func (f *filters) maskValue(v log.Value) log.Value {
+	if len(f.maskPatterns) == 0 {
+		return v
+	}
+	switch v.Kind() {
+	case log.KindString:
+		return log.StringValue(maskString(f.maskPatterns, v.AsString()))
+	case log.KindSlice:
+		items := v.AsSlice()
+		masked := make([]log.Value, 0, len(items))
+		for _, item := range items {
+			masked = append(masked, f.maskValue(item))
+		}
+		return log.SliceValue(masked...)
+	case log.KindMap:
+		kvs := v.AsMap()
+		masked := make([]log.KeyValue, 0, len(kvs))
+		for _, kv := range kvs {
+			masked = append(masked, log.KeyValue{Key: kv.Key, Value: f.maskValue(kv.Value)})
+		}
+		return log.MapValue(masked...)
+	default:
+		return v
+	}
+}
+
+// maskString replaces the substrings of s matching any of the patterns.
+func maskString(patterns []*regexp.Regexp, s string) string {
+	for _, p := range patterns {
+		s = p.ReplaceAllLiteralString(s, maskReplacement)
+	}
+	return s
+}
@@ -0,0 +1,401 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+)
+
+func TestLogger_MaskPatterns(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{
+		Provider:     recorder,
+		Name:         "test-logger",
+		MaskPatterns: []*regexp.Regexp{regexp.MustCompile(`tok_[A-Za-z0-9]+`)},
+	})
+
+	ctx := t.Context()
+	logger.With("auth", "Bearer tok_abc123").Info(ctx, "login with tok_xyz789 succeeded",
+		"headers", map[string]string{"Authorization": "tok_def456"},
+		"count", 3,
+	)
+
+	want := logtest.Recording{
+		logtest.Scope{
+			Name: "test-logger",
+		}: {
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityInfo,
+				Body:     log.StringValue("login with *** succeeded"),
+				Attributes: []log.KeyValue{
+					log.String("auth", "Bearer ***"),
+					log.Map("headers", log.String("Authorization", "***")),
+					log.Int64("count", 3),
+				},
+			},
+		},
+	}
+
+	got := recorder.Result()
+	logtest.AssertEqual(t, want, got, logtest.Transform(func(r logtest.Record) logtest.Record {
+		r.Timestamp = time.Time{}
+		r.ObservedTimestamp = time.Time{}
+		return r
+	}))
+}
+
+func TestLogger_OmitEmpty(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger", OmitEmpty: true})
+
+	ctx := t.Context()
+	logger.With("empty_with", "", "service", "api").Info(ctx, "",
+		"empty", "",
+		"bytes", []byte{},
+		"nil", nil,
+		"zero", 0,
+		"name", "value",
+	)
+
+	want := logtest.Recording{
+		logtest.Scope{
+			Name: "test-logger",
+		}: {
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityInfo,
+				Body:     log.StringValue(""),
+				Attributes: []log.KeyValue{
+					log.String("service", "api"),
+					log.Int64("zero", 0),
+					log.String("name", "value"),
+				},
+			},
+		},
+	}
+
+	got := recorder.Result()
+	logtest.AssertEqual(t, want, got, logtest.Transform(func(r logtest.Record) logtest.Record {
+		r.Timestamp = time.Time{}
+		r.ObservedTimestamp = time.Time{}
+		return r
+	}))
+}
+
+func TestLogger_MaxRecordBytes(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	// The body takes 10 bytes and each "kN"=int attribute takes 10 bytes.
+	logger := New(Options{Provider: recorder, Name: "test-logger", MaxRecordBytes: 35})
+
+	ctx := t.Context()
+	logger.Info(ctx, "0123456789", "k1", 1, "k2", 2, "k3", 3, "k4", 4)
+	logger.Info(ctx, "0123456789", "k1", 1)
+
+	want := logtest.Recording{
+		logtest.Scope{
+			Name: "test-logger",
+		}: {
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityInfo,
+				Body:     log.StringValue("0123456789"),
+				Attributes: []log.KeyValue{
+					log.Int64("k1", 1),
+					log.Int64("k2", 2),
+					log.Bool("log.truncated", true),
+				},
+			},
+			logtest.Record{
+				Context:    ctx,
+				Severity:   log.SeverityInfo,
+				Body:       log.StringValue("0123456789"),
+				Attributes: []log.KeyValue{log.Int64("k1", 1)},
+			},
+		},
+	}
+
+	got := recorder.Result()
+	logtest.AssertEqual(t, want, got, logtest.Transform(func(r logtest.Record) logtest.Record {
+		r.Timestamp = time.Time{}
+		r.ObservedTimestamp = time.Time{}
+		return r
+	}))
+}
+
+func TestLogger_MaxRecordBytesBody(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger", MaxRecordBytes: 5})
+
+	ctx := t.Context()
+	logger.Info(ctx, "a body over the budget", "key", "value")
+
+	want := logtest.Recording{
+		logtest.Scope{
+			Name: "test-logger",
+		}: {
+			logtest.Record{
+				Context:    ctx,
+				Severity:   log.SeverityInfo,
+				Body:       log.StringValue("a body over the budget"),
+				Attributes: []log.KeyValue{log.Bool("log.truncated", true)},
+			},
+		},
+	}
+
+	got := recorder.Result()
+	logtest.AssertEqual(t, want, got, logtest.Transform(func(r logtest.Record) logtest.Record {
+		r.Timestamp = time.Time{}
+		r.ObservedTimestamp = time.Time{}
+		return r
+	}))
+}
+
+func TestValueSize(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		value log.Value
+		want  int
+	}{
+		{name: "empty", value: log.Value{}, want: 0},
+		{name: "string", value: log.StringValue("abc"), want: 3},
+		{name: "bytes", value: log.BytesValue([]byte{1, 2}), want: 2},
+		{name: "int64", value: log.Int64Value(1), want: 8},
+		{name: "float64", value: log.Float64Value(1), want: 8},
+		{name: "bool", value: log.BoolValue(true), want: 1},
+		{name: "slice", value: log.SliceValue(log.StringValue("ab"), log.BoolValue(false)), want: 3},
+		{name: "map", value: log.MapValue(log.String("key", "ab")), want: 5},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := valueSize(tt.value); got != tt.want {
+				t.Errorf("valueSize() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func validateKey(kv log.KeyValue) error {
+	if kv.Key == "" {
+		return errors.New("empty key")
+	}
+	if strings.Contains(kv.Key, " ") {
+		return errors.New("key contains a space")
+	}
+	return nil
+}
+
+func TestLogger_ValidateAttr(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	var errs []error
+	logger := New(Options{
+		Provider:     recorder,
+		Name:         "test-logger",
+		ValidateAttr: validateKey,
+		OnError:      func(err error) { errs = append(errs, err) },
+	})
+
+	ctx := t.Context()
+	logger.With("", "with").InfoAttr(ctx, "msg",
+		log.String("valid", "a"),
+		log.String("user id", "b"),
+		log.Int("count", 1),
+	)
+
+	want := logtest.Recording{
+		logtest.Scope{
+			Name: "test-logger",
+		}: {
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityInfo,
+				Body:     log.StringValue("msg"),
+				Attributes: []log.KeyValue{
+					log.String("valid", "a"),
+					log.Int("count", 1),
+				},
+			},
+		},
+	}
+
+	got := recorder.Result()
+	logtest.AssertEqual(t, want, got, logtest.Transform(func(r logtest.Record) logtest.Record {
+		r.Timestamp = time.Time{}
+		r.ObservedTimestamp = time.Time{}
+		return r
+	}))
+
+	if assert.Len(t, errs, 2) {
+		assert.EqualError(t, errs[0], `olog: invalid attribute "": empty key`)
+		assert.EqualError(t, errs[1], `olog: invalid attribute "user id": key contains a space`)
+	}
+}
+
+func TestLogger_ValidateAttrStrict(t *testing.T) {
+	logger := New(Options{
+		Provider:     logtest.NewRecorder(),
+		Name:         "test-logger",
+		ValidateAttr: validateKey,
+		Strict:       true,
+	})
+
+	ctx := t.Context()
+	assert.NotPanics(t, func() { logger.Info(ctx, "msg", "valid", 1) })
+	assert.Panics(t, func() { logger.Info(ctx, "msg", "", 1) })
+}
+
+func TestLogger_AttrPrefix(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{
+		Provider:      recorder,
+		Name:          "test-logger",
+		AttrPrefix:    "tenant_a.",
+		SeverityAttrs: map[log.Severity][]log.KeyValue{log.SeverityError: {log.Bool("alert", true)}},
+	})
+
+	ctx := t.Context()
+	child := logger.With("service", "api").WithAttr(log.String("region", "eu"))
+	child.Info(ctx, "args", "user.id", 42)
+	child.ErrorAttr(ctx, "attrs", log.Int("user.id", 42))
+	child.InfoEvent(ctx, "user.login", "user.id", 42)
+
+	want := logtest.Recording{
+		logtest.Scope{
+			Name: "test-logger",
+		}: {
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityInfo,
+				Body:     log.StringValue("args"),
+				Attributes: []log.KeyValue{
+					log.String("tenant_a.service", "api"),
+					log.String("tenant_a.region", "eu"),
+					log.Int64("tenant_a.user.id", 42),
+				},
+			},
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityError,
+				Body:     log.StringValue("attrs"),
+				Attributes: []log.KeyValue{
+					log.String("tenant_a.service", "api"),
+					log.String("tenant_a.region", "eu"),
+					log.Int("tenant_a.user.id", 42),
+					log.Bool("tenant_a.alert", true),
+				},
+			},
+			logtest.Record{
+				Context:   ctx,
+				Severity:  log.SeverityInfo,
+				EventName: "user.login",
+				Attributes: []log.KeyValue{
+					log.String("tenant_a.service", "api"),
+					log.String("tenant_a.region", "eu"),
+					log.Int64("tenant_a.user.id", 42),
+				},
+			},
+		},
+	}
+
+	got := recorder.Result()
+	logtest.AssertEqual(t, want, got, logtest.Transform(func(r logtest.Record) logtest.Record {
+		r.Timestamp = time.Time{}
+		r.ObservedTimestamp = time.Time{}
+		return r
+	}))
+}
+
+func TestLogger_KeyAliases(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{
+		Provider:   recorder,
+		Name:       "test-logger",
+		KeyAliases: map[string]string{"uid": "user.id", "svc": "service.name"},
+		AttrPrefix: "app.",
+	})
+
+	ctx := t.Context()
+	child := logger.With("svc", "api")
+	child.Info(ctx, "args", "uid", 42, "role", "admin")
+	child.InfoEvent(ctx, "user.login", "uid", 42)
+
+	want := logtest.Recording{
+		logtest.Scope{
+			Name: "test-logger",
+		}: {
+			logtest.Record{
+				Context:  ctx,
+				Severity: log.SeverityInfo,
+				Body:     log.StringValue("args"),
+				Attributes: []log.KeyValue{
+					log.String("app.service.name", "api"),
+					log.Int64("app.user.id", 42),
+					log.String("app.role", "admin"),
+				},
+			},
+			logtest.Record{
+				Context:   ctx,
+				Severity:  log.SeverityInfo,
+				EventName: "user.login",
+				Attributes: []log.KeyValue{
+					log.String("app.service.name", "api"),
+					log.Int64("app.user.id", 42),
+				},
+			},
+		},
+	}
+
+	got := recorder.Result()
+	logtest.AssertEqual(t, want, got, logtest.Transform(func(r logtest.Record) logtest.Record {
+		r.Timestamp = time.Time{}
+		r.ObservedTimestamp = time.Time{}
+		return r
+	}))
+}
+
+func TestLogger_ValueTransformers(t *testing.T) {
+	hashEmail := func(key string, v log.Value) (log.Value, bool) {
+		if !strings.HasSuffix(key, ".email") || v.Kind() != log.KindString {
+			return v, false
+		}
+		sum := sha256.Sum256([]byte(v.AsString()))
+		return log.StringValue(hex.EncodeToString(sum[:])), true
+	}
+	neverCalled := func(key string, v log.Value) (log.Value, bool) {
+		if key == "user.email" {
+			t.Errorf("transformer called after the first matching one for %q", key)
+		}
+		return v, false
+	}
+	recorder := logtest.NewRecorder()
+	logger := New(Options{
+		Provider:          recorder,
+		Name:              "test-logger",
+		ValueTransformers: []func(string, log.Value) (log.Value, bool){hashEmail, neverCalled},
+	})
+
+	ctx := t.Context()
+	logger.With("user.email", "a@example.com").InfoEvent(ctx, "signup", "user.name", "a", "admin.email", 1)
+
+	sum := sha256.Sum256([]byte("a@example.com"))
+	want := []log.KeyValue{
+		log.String("user.email", hex.EncodeToString(sum[:])),
+		log.String("user.name", "a"),
+		log.Int64("admin.email", 1),
+	}
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	if assert.Len(t, records, 1) {
+		assert.Equal(t, want, records[0].Attributes)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import (
+	"math"
+	"strconv"
+	"sync"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// withCache memoizes the attributes converted from the arguments of With.
+// The cached attributes are shared and must not be modified.
+type withCache struct {
+	mu    sync.Mutex
+	size  int
+	attrs map[string][]log.KeyValue
+}
+
+// newWithCache returns a cache holding up to size entries,
+// or nil if size is not positive.
+func newWithCache(size int) *withCache {
+	if size <= 0 {
+		return nil
+	}
+	return &withCache{size: size, attrs: make(map[string][]log.KeyValue, size)}
+}
+
+// get returns the attributes cached under key.
+func (c *withCache) get(key []byte) ([]log.KeyValue, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	attrs, ok := c.attrs[string(key)]
+	return attrs, ok
+}
+
+// put caches attrs under key. The cache is emptied when it is full.
+func (c *withCache) put(key []byte, attrs []log.KeyValue) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.attrs) >= c.size {
+		clear(c.attrs)
+	}
+	c.attrs[string(key)] = attrs
+}
+
+// appendWithCacheKey appends the cache key of the namespace and args to dst.
+// It returns false if any of the arguments is not a string, bool, int, int64,
+// or float64, whose values identify the converted attributes.
+func appendWithCacheKey(dst []byte, namespace string, args []any) ([]byte, bool) {
+	dst = appendKeyString(dst, namespace)
+	for _, arg := range args {
+		switch v := arg.(type) {
+		case string:
+			dst = appendKeyString(append(dst, 's'), v)
+		case bool:
+			dst = strconv.AppendBool(append(dst, 'b'), v)
+		case int:
+			dst = strconv.AppendInt(append(dst, 'i'), int64(v), 10)
+		case int64:
+			dst = strconv.AppendInt(append(dst, 'l'), v, 10)
+		case float64:
+			dst = strconv.AppendUint(append(dst, 'f'), math.Float64bits(v), 16)
+		default:
+			return dst, false
+		}
+		dst = append(dst, 0)
+	}
+	return dst, true
+}
+
+// appendKeyString appends s prefixed with its length to dst,
+// so that strings containing separators cannot collide.
+func appendKeyString(dst []byte, s string) []byte {
+	dst = strconv.AppendInt(dst, int64(len(s)), 10)
+	dst = append(dst, ':')
+	return append(dst, s...)
+}
+
+// withKeyValues returns the namespaced attributes converted from the arguments of With.
+// If Options.WithCacheSize is set, the attributes of arguments with the same
+// values are converted once and shared.
+func (l *Logger) withKeyValues(args []any) []log.KeyValue {
+	cache := l.cfg.withCache
+	if cache == nil {
+		return l.convertWithArgs(args)
+	}
+	var buf [128]byte
+	key, ok := appendWithCacheKey(buf[:0], l.namespace, args)
+	if !ok {
+		return l.convertWithArgs(args)
+	}
+	if attrs, ok := cache.get(key); ok {
+		return attrs
+	}
+	attrs := l.convertWithArgs(args)
+	cache.put(key, attrs)
+	return attrs
+}
+
+// convertWithArgs converts the arguments of With to namespaced attributes.
+func (l *Logger) convertWithArgs(args []any) []log.KeyValue {
+	attrs := l.convertArgsToKeyValues(args)
+	// The converted attributes are not shared, so they can be prefixed in place.
+	return l.appendNamespaced(attrs[:0], attrs)
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+)
+
+func TestLogger_WithCache(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := New(Options{Provider: recorder, Name: "test-logger", WithCacheSize: 8})
+
+	first := logger.With("service", "x", "version", "y", "replicas", 3)
+	second := logger.With("service", "x", "version", "y", "replicas", 3)
+	other := logger.With("service", "x", "version", "z", "replicas", 3)
+	namespaced := logger.WithNamespace("app").With("service", "x", "version", "y", "replicas", 3)
+
+	want := []log.KeyValue{
+		log.String("service", "x"),
+		log.String("version", "y"),
+		log.Int64("replicas", 3),
+	}
+	assert.Equal(t, want, first.attrs.All())
+	assert.Equal(t, want, second.attrs.All())
+	assert.Same(t, &first.attrs.attrs[0], &second.attrs.attrs[0], "identical With calls should share the cached attributes")
+	assert.Equal(t, log.String("version", "z"), other.attrs.All()[1])
+	assert.Equal(t, log.String("app.service", "x"), namespaced.attrs.All()[0])
+
+	ctx := t.Context()
+	first.Info(ctx, "first")
+	second.Info(ctx, "second", "extra", true)
+
+	records := recorder.Result()[logtest.Scope{Name: "test-logger"}]
+	if assert.Len(t, records, 2) {
+		assert.Equal(t, want, records[0].Attributes)
+		assert.Equal(t, append(want, log.Bool("extra", true)), records[1].Attributes)
+	}
+}
+
+func TestLogger_WithCacheUncacheable(t *testing.T) {
+	logger := New(Options{Provider: logtest.NewRecorder(), Name: "test-logger", WithCacheSize: 8})
+
+	first := logger.With("ids", []int{1})
+	second := logger.With("ids", []int{1})
+
+	assert.Equal(t, first.attrs.All(), second.attrs.All())
+	assert.NotSame(t, &first.attrs.attrs[0], &second.attrs.attrs[0])
+	assert.Empty(t, logger.cfg.withCache.attrs)
+}
+
+func TestWithCache_Bounded(t *testing.T) {
+	c := newWithCache(2)
+	c.put([]byte("a"), []log.KeyValue{log.Int("a", 1)})
+	c.put([]byte("b"), []log.KeyValue{log.Int("b", 2)})
+	c.put([]byte("c"), []log.KeyValue{log.Int("c", 3)})
+
+	assert.Len(t, c.attrs, 1)
+	_, ok := c.get([]byte("c"))
+	assert.True(t, ok)
+}
+
+func TestAppendWithCacheKey(t *testing.T) {
+	key := func(namespace string, args ...any) string {
+		b, ok := appendWithCacheKey(nil, namespace, args)
+		if !ok {
+			return "<uncacheable>"
+		}
+		return string(b)
+	}
+
+	assert.Equal(t, key("", "a", 1), key("", "a", 1))
+	assert.NotEqual(t, key("", "a", 1), key("", "a", int64(1)))
+	assert.NotEqual(t, key("", "a", "1"), key("", "a", 1))
+	assert.NotEqual(t, key("", "ab", "c"), key("", "a", "bc"))
+	assert.NotEqual(t, key("ns.", "a", 1), key("", "a", 1))
+	assert.NotEqual(t, key("", "f", 1.5), key("", "f", 2.5))
+	assert.Equal(t, "<uncacheable>", key("", "a", []int{1}))
+}
@@ -0,0 +1,19 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import "go.opentelemetry.io/otel/log"
+
+// Wrap returns a Logger emitting the log records with the given log.Logger,
+// for example one obtained from a custom provider setup, with the default options.
+// As the LoggerProvider is unknown, Flush does nothing and Named
+// keeps emitting with l.
+func Wrap(l log.Logger) *Logger {
+	cfg := &config{}
+	cfg.filters.Store(&filters{})
+	return &Logger{
+		Logger: l,
+		cfg:    cfg,
+	}
+}
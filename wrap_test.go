@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/logtest"
+)
+
+func TestWrap(t *testing.T) {
+	recorder := logtest.NewRecorder()
+	logger := Wrap(recorder.Logger("wrapped", log.WithInstrumentationVersion("v1.0.0")))
+
+	ctx := t.Context()
+	logger.With("service", "api").Info(ctx, "hello", "n", 1)
+	logger.WarnEvent(ctx, "event")
+	logger.Named("child").Error(ctx, "named")
+	require.NoError(t, logger.Flush(ctx))
+
+	records := recorder.Result()[logtest.Scope{Name: "wrapped", Version: "v1.0.0"}]
+	require.Len(t, records, 3)
+	assert.Equal(t, log.StringValue("hello"), records[0].Body)
+	assert.Equal(t, log.SeverityInfo, records[0].Severity)
+	assert.Equal(t, []log.KeyValue{log.String("service", "api"), log.Int64("n", 1)}, records[0].Attributes)
+	assert.Equal(t, "event", records[1].EventName)
+	assert.Equal(t, log.StringValue("named"), records[2].Body)
+}
@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog // import "github.com/pellared/olog"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/log"
+)
+
+// The methods in this file are aliases easing the migration from
+// the go.uber.org/zap SugaredLogger.
+
+// Debugw is an alias for Debug.
+func (l *Logger) Debugw(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, log.SeverityDebug, msg, args)
+}
+
+// Infow is an alias for Info.
+func (l *Logger) Infow(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, log.SeverityInfo, msg, args)
+}
+
+// Warnw is an alias for Warn.
+func (l *Logger) Warnw(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, log.SeverityWarn, msg, args)
+}
+
+// Errorw is an alias for Error.
+func (l *Logger) Errorw(ctx context.Context, msg string, args ...any) {
+	l.log(ctx, log.SeverityError, msg, args)
+}
+
+// Sync is an alias for Flush with a background context.
+func (l *Logger) Sync() error {
+	return l.Flush(context.Background())
+}
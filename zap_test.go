@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package olog
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/log/logtest"
+)
+
+func TestLogger_ZapAliases(t *testing.T) {
+	ctx := t.Context()
+
+	emit := func(f func(l *Logger)) logtest.Recording {
+		recorder := logtest.NewRecorder()
+		f(New(Options{Provider: recorder, Name: "test-logger"}))
+		return recorder.Result()
+	}
+
+	want := emit(func(l *Logger) {
+		l.Debug(ctx, "message", "key", "value")
+		l.Info(ctx, "message", "key", "value")
+		l.Warn(ctx, "message", "key", "value")
+		l.Error(ctx, "message", "key", "value")
+	})
+	got := emit(func(l *Logger) {
+		l.Debugw(ctx, "message", "key", "value")
+		l.Infow(ctx, "message", "key", "value")
+		l.Warnw(ctx, "message", "key", "value")
+		l.Errorw(ctx, "message", "key", "value")
+	})
+
+	logtest.AssertEqual(t, want, got, logtest.Transform(func(r logtest.Record) logtest.Record {
+		r.Timestamp = time.Time{}
+		r.ObservedTimestamp = time.Time{}
+		return r
+	}))
+}
+
+type flushRecorder struct {
+	*logtest.Recorder
+	flushes int
+	err     error
+}
+
+func (r *flushRecorder) ForceFlush(context.Context) error {
+	r.flushes++
+	return r.err
+}
+
+func TestLogger_Sync(t *testing.T) {
+	provider := &flushRecorder{Recorder: logtest.NewRecorder(), err: errors.New("flush failed")}
+	logger := New(Options{Provider: provider, Name: "test-logger"})
+
+	err := logger.With("key", "value").Sync()
+	if !errors.Is(err, provider.err) {
+		t.Errorf("got error %v, want %v", err, provider.err)
+	}
+	if provider.flushes != 1 {
+		t.Errorf("expected 1 flush, got %d", provider.flushes)
+	}
+}
+
+func TestLogger_SyncWithoutFlusher(t *testing.T) {
+	logger := New(Options{Provider: logtest.NewRecorder(), Name: "test-logger"})
+
+	if err := logger.Sync(); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}